@@ -0,0 +1,201 @@
+// Package dialcheck provides a health check function that evaluates a TCP or Unix domain socket endpoint by dialing it.
+package dialcheck
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"net"
+	"time"
+
+	"github.com/jaredpetersen/go-health/health"
+)
+
+// Details contains information about the connection made by the check function. It implements the optional detail
+// interfaces of the healthhttp package, so the latency is reported as the observed value of the IETF encoder.
+type Details struct {
+	// Network is the network that was dialed, e.g. "tcp" or "unix".
+	Network string
+	// Address is the address that was dialed.
+	Address string
+	// ResolvedAddress is the remote address of the connection. Empty if the connection could not be established.
+	ResolvedAddress string
+	// Latency is the time it took to establish the connection.
+	Latency time.Duration
+	// Response contains the bytes that were read from the connection when a response is expected.
+	Response string
+	// Error describes why the endpoint is not considered healthy. Empty if the connection passed all of the
+	// assertions.
+	Error string
+}
+
+// ObservedValue returns the latency in milliseconds.
+func (details Details) ObservedValue() interface{} {
+	return details.Latency.Milliseconds()
+}
+
+// ObservedUnit returns the unit of the latency.
+func (details Details) ObservedUnit() string {
+	return "ms"
+}
+
+// MeasurementName returns the name of the latency measurement.
+func (details Details) MeasurementName() string {
+	return "connectTime"
+}
+
+// Output returns the reason that the endpoint is not considered healthy.
+func (details Details) Output() string {
+	return details.Error
+}
+
+// checker evaluates a socket endpoint.
+type checker struct {
+	// network is the network that is dialed.
+	network string
+	// address is the address that is dialed.
+	address string
+	// dialer establishes the connection.
+	dialer *net.Dialer
+	// probe is written to the connection once it has been established. Nil if nothing is written.
+	probe []byte
+	// expectedPrefix is the prefix that the response must start with. Nil if no response is expected.
+	expectedPrefix []byte
+	// warnLatency is the latency above which the endpoint is reported as StateWarn. Zero if disabled.
+	warnLatency time.Duration
+}
+
+// Option is used to configure optional check behavior.
+type Option func(checker *checker)
+
+// WithDialer configures the dialer used to establish the connection, e.g. to set a local address or keep-alive
+// period. Defaults to a dialer with the default settings.
+func WithDialer(dialer *net.Dialer) Option {
+	return func(checker *checker) {
+		checker.dialer = dialer
+	}
+}
+
+// WithProbe configures a payload that is written to the connection once it has been established, e.g. a protocol
+// specific ping command.
+func WithProbe(payload []byte) Option {
+	return func(checker *checker) {
+		checker.probe = payload
+	}
+}
+
+// WithExpectedPrefix requires the endpoint to respond with data that starts with the provided prefix, e.g. the "220"
+// greeting of an SMTP server. The response is read after the probe, if any, has been written.
+func WithExpectedPrefix(prefix []byte) Option {
+	return func(checker *checker) {
+		checker.expectedPrefix = prefix
+	}
+}
+
+// WithWarnLatency configures the connect latency above which an otherwise healthy endpoint is reported as StateWarn.
+func WithWarnLatency(threshold time.Duration) Option {
+	return func(checker *checker) {
+		checker.warnLatency = threshold
+	}
+}
+
+// New creates a health check function that dials the provided address on the provided network, which may be any
+// network supported by net.Dial, e.g. "tcp", "tcp4", or "unix". By default, the endpoint is reported as StateUp if the
+// connection can be established and StateDown otherwise. The connection is closed before the check function returns.
+// The status details are always of type Details. Dialing, writing, and reading are terminated when the context
+// provided to the check function is done, so configure a timeout on the check. The return value will never be nil.
+func New(network string, address string, opts ...Option) health.CheckFunc {
+	checker := &checker{
+		network: network,
+		address: address,
+		dialer:  &net.Dialer{},
+	}
+
+	for _, opt := range opts {
+		opt(checker)
+	}
+
+	return checker.check
+}
+
+// check dials the endpoint and evaluates the connection.
+func (checker *checker) check(ctx context.Context) health.Status {
+	details := Details{Network: checker.network, Address: checker.address}
+
+	start := time.Now()
+	conn, err := checker.dialer.DialContext(ctx, checker.network, checker.address)
+	details.Latency = time.Since(start)
+	if err != nil {
+		return down(details, err)
+	}
+	defer conn.Close()
+
+	details.ResolvedAddress = conn.RemoteAddr().String()
+
+	if checker.probe != nil || checker.expectedPrefix != nil {
+		if err := exchange(ctx, conn, checker.probe, checker.expectedPrefix, &details); err != nil {
+			return down(details, err)
+		}
+	}
+
+	if checker.warnLatency > 0 && details.Latency > checker.warnLatency {
+		details.Error = fmt.Sprintf("latency exceeded %s", checker.warnLatency)
+		return health.Status{State: health.StateWarn, Details: details}
+	}
+
+	return health.Status{State: health.StateUp, Details: details}
+}
+
+// exchange writes the probe to the connection and reads the response, verifying that it starts with the expected
+// prefix. The connection is closed early if the context is done.
+func exchange(ctx context.Context, conn net.Conn, probe []byte, expectedPrefix []byte, details *Details) error {
+	if deadline, ok := ctx.Deadline(); ok {
+		if err := conn.SetDeadline(deadline); err != nil {
+			return err
+		}
+	}
+
+	// Unblock reads and writes if the context is cancelled without a deadline
+	done := make(chan struct{})
+	defer close(done)
+	go func() {
+		select {
+		case <-ctx.Done():
+			conn.Close()
+		case <-done:
+		}
+	}()
+
+	if probe != nil {
+		if _, err := conn.Write(probe); err != nil {
+			return fmt.Errorf("failed to write probe: %w", err)
+		}
+	}
+
+	if expectedPrefix == nil {
+		return nil
+	}
+
+	response := make([]byte, len(expectedPrefix))
+	n, err := io.ReadFull(conn, response)
+	details.Response = string(response[:n])
+	if err != nil {
+		if ctx.Err() != nil {
+			err = ctx.Err()
+		}
+		return fmt.Errorf("failed to read response: %w", err)
+	}
+
+	if !bytes.Equal(response, expectedPrefix) {
+		return fmt.Errorf("response %q does not start with %q", response, expectedPrefix)
+	}
+
+	return nil
+}
+
+// down creates a StateDown status with the provided error recorded in the details.
+func down(details Details, err error) health.Status {
+	details.Error = err.Error()
+	return health.Status{State: health.StateDown, Details: details}
+}
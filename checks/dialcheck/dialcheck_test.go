@@ -0,0 +1,169 @@
+package dialcheck_test
+
+import (
+	"bufio"
+	"context"
+	"net"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/jaredpetersen/go-health/checks/dialcheck"
+	"github.com/jaredpetersen/go-health/health"
+	"github.com/stretchr/testify/assert"
+)
+
+// listen starts accepting connections on the network and hands each of them to the handler.
+func listen(t *testing.T, network string, address string, handler func(conn net.Conn)) net.Listener {
+	listener, err := net.Listen(network, address)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { listener.Close() })
+
+	go func() {
+		for {
+			conn, err := listener.Accept()
+			if err != nil {
+				return
+			}
+			go func() {
+				defer conn.Close()
+				handler(conn)
+			}()
+		}
+	}()
+
+	return listener
+}
+
+func TestCheckTCP(t *testing.T) {
+	listener := listen(t, "tcp", "127.0.0.1:0", func(conn net.Conn) {})
+
+	status := dialcheck.New("tcp", listener.Addr().String())(context.Background())
+
+	assert.Equal(t, health.StateUp, status.State)
+	details := status.Details.(dialcheck.Details)
+	assert.Equal(t, "tcp", details.Network)
+	assert.Equal(t, listener.Addr().String(), details.Address)
+	assert.Equal(t, listener.Addr().String(), details.ResolvedAddress)
+	assert.True(t, details.Latency > 0, "Latency is not set")
+	assert.Empty(t, details.Error)
+}
+
+func TestCheckUnix(t *testing.T) {
+	address := filepath.Join(t.TempDir(), "health.sock")
+	listen(t, "unix", address, func(conn net.Conn) {})
+
+	status := dialcheck.New("unix", address)(context.Background())
+
+	assert.Equal(t, health.StateUp, status.State)
+	assert.Equal(t, address, status.Details.(dialcheck.Details).ResolvedAddress)
+}
+
+func TestCheckConnectionRefused(t *testing.T) {
+	listener := listen(t, "tcp", "127.0.0.1:0", func(conn net.Conn) {})
+	address := listener.Addr().String()
+	listener.Close()
+
+	status := dialcheck.New("tcp", address)(context.Background())
+
+	assert.Equal(t, health.StateDown, status.State)
+	details := status.Details.(dialcheck.Details)
+	assert.Empty(t, details.ResolvedAddress)
+	assert.NotEmpty(t, details.Error)
+}
+
+func TestCheckBanner(t *testing.T) {
+	listener := listen(t, "tcp", "127.0.0.1:0", func(conn net.Conn) {
+		conn.Write([]byte("220 smtp.example.com ESMTP\r\n"))
+	})
+
+	status := dialcheck.New("tcp", listener.Addr().String(),
+		dialcheck.WithExpectedPrefix([]byte("220 ")))(context.Background())
+
+	assert.Equal(t, health.StateUp, status.State)
+	assert.Equal(t, "220 ", status.Details.(dialcheck.Details).Response)
+}
+
+func TestCheckUnexpectedBanner(t *testing.T) {
+	listener := listen(t, "tcp", "127.0.0.1:0", func(conn net.Conn) {
+		conn.Write([]byte("554 go away\r\n"))
+	})
+
+	status := dialcheck.New("tcp", listener.Addr().String(),
+		dialcheck.WithExpectedPrefix([]byte("220 ")))(context.Background())
+
+	assert.Equal(t, health.StateDown, status.State)
+	details := status.Details.(dialcheck.Details)
+	assert.Equal(t, "554 ", details.Response)
+	assert.Equal(t, `response "554 " does not start with "220 "`, details.Error)
+}
+
+func TestCheckProbe(t *testing.T) {
+	listener := listen(t, "tcp", "127.0.0.1:0", func(conn net.Conn) {
+		line, err := bufio.NewReader(conn).ReadString('\n')
+		if err == nil && line == "PING\r\n" {
+			conn.Write([]byte("+PONG\r\n"))
+		}
+	})
+
+	status := dialcheck.New("tcp", listener.Addr().String(),
+		dialcheck.WithProbe([]byte("PING\r\n")),
+		dialcheck.WithExpectedPrefix([]byte("+PONG")))(context.Background())
+
+	assert.Equal(t, health.StateUp, status.State)
+}
+
+func TestCheckResponseTimeout(t *testing.T) {
+	unblock := make(chan struct{})
+	defer close(unblock)
+	listener := listen(t, "tcp", "127.0.0.1:0", func(conn net.Conn) {
+		<-unblock
+	})
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Millisecond*20)
+	defer cancel()
+
+	status := dialcheck.New("tcp", listener.Addr().String(),
+		dialcheck.WithExpectedPrefix([]byte("220 ")))(ctx)
+
+	assert.Equal(t, health.StateDown, status.State)
+	assert.Contains(t, status.Details.(dialcheck.Details).Error, "failed to read response")
+}
+
+func TestCheckResponseCancelled(t *testing.T) {
+	unblock := make(chan struct{})
+	defer close(unblock)
+	listener := listen(t, "tcp", "127.0.0.1:0", func(conn net.Conn) {
+		<-unblock
+	})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	time.AfterFunc(time.Millisecond*20, cancel)
+
+	status := dialcheck.New("tcp", listener.Addr().String(),
+		dialcheck.WithExpectedPrefix([]byte("220 ")))(ctx)
+
+	assert.Equal(t, health.StateDown, status.State)
+	assert.Equal(t, "failed to read response: context canceled", status.Details.(dialcheck.Details).Error)
+}
+
+func TestCheckWarnLatency(t *testing.T) {
+	listener := listen(t, "tcp", "127.0.0.1:0", func(conn net.Conn) {})
+
+	status := dialcheck.New("tcp", listener.Addr().String(),
+		dialcheck.WithWarnLatency(time.Nanosecond))(context.Background())
+
+	assert.Equal(t, health.StateWarn, status.State)
+	assert.Equal(t, "latency exceeded 1ns", status.Details.(dialcheck.Details).Error)
+}
+
+func TestDetails(t *testing.T) {
+	details := dialcheck.Details{Latency: time.Millisecond * 5, Error: "connection refused"}
+
+	assert.Equal(t, int64(5), details.ObservedValue())
+	assert.Equal(t, "ms", details.ObservedUnit())
+	assert.Equal(t, "connectTime", details.MeasurementName())
+	assert.Equal(t, "connection refused", details.Output())
+}
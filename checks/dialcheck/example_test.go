@@ -0,0 +1,34 @@
+package dialcheck_test
+
+import (
+	"context"
+	"time"
+
+	"github.com/jaredpetersen/go-health/checks/dialcheck"
+	"github.com/jaredpetersen/go-health/health"
+)
+
+func Example() {
+	// Create the health monitor that will be polling the resources.
+	healthMonitor := health.New()
+
+	// Prepare the context -- this can be used to stop async monitoring.
+	ctx := context.Background()
+
+	// Create your health checks.
+	smtpHealthCheckFunc := dialcheck.New("tcp", "smtp.example.com:25", dialcheck.WithExpectedPrefix([]byte("220 ")))
+	smtpHealthCheck := health.NewCheck("smtp", smtpHealthCheckFunc)
+	smtpHealthCheck.Timeout = time.Second * 2
+	healthMonitor.Monitor(ctx, smtpHealthCheck)
+
+	agentHealthCheckFunc := dialcheck.New("unix", "/var/run/agent.sock")
+	agentHealthCheck := health.NewCheck("agent", agentHealthCheckFunc)
+	agentHealthCheck.Timeout = time.Second * 2
+	healthMonitor.Monitor(ctx, agentHealthCheck)
+
+	// Wait for all of the checks to execute at least once.
+	healthMonitor.WaitReady(ctx)
+
+	// Retrieve the most recent cached result for all of the checks.
+	healthMonitor.Check()
+}
@@ -0,0 +1,184 @@
+// Package diskcheck provides a health check function that evaluates the free space and inodes of filesystems.
+package diskcheck
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/jaredpetersen/go-health/health"
+)
+
+// Path is a path on a filesystem to evaluate along with the thresholds that apply to it. Thresholds left at their
+// zero-value are not evaluated. The check is reported as StateWarn or StateDown when the free space or inodes fall
+// below the respective threshold.
+type Path struct {
+	// Path is any path on the filesystem, typically the mount point.
+	Path string
+	// WarnFreeBytes is the number of free bytes below which the check is reported as StateWarn.
+	WarnFreeBytes uint64
+	// DownFreeBytes is the number of free bytes below which the check is reported as StateDown.
+	DownFreeBytes uint64
+	// WarnFreePercent is the percentage (0 to 100) of free bytes below which the check is reported as StateWarn.
+	WarnFreePercent float64
+	// DownFreePercent is the percentage (0 to 100) of free bytes below which the check is reported as StateDown.
+	DownFreePercent float64
+	// WarnFreeInodes is the number of free inodes below which the check is reported as StateWarn. Ignored for
+	// filesystems that do not report inodes.
+	WarnFreeInodes uint64
+	// DownFreeInodes is the number of free inodes below which the check is reported as StateDown. Ignored for
+	// filesystems that do not report inodes.
+	DownFreeInodes uint64
+}
+
+// PathDetails contains the usage of the filesystem that a path belongs to.
+type PathDetails struct {
+	// Path is the path that was evaluated.
+	Path string
+	// State is the state of the path according to its thresholds.
+	State health.State
+	// TotalBytes is the size of the filesystem.
+	TotalBytes uint64
+	// FreeBytes is the number of bytes available to unprivileged users.
+	FreeBytes uint64
+	// FreePercent is the percentage (0 to 100) of the filesystem available to unprivileged users.
+	FreePercent float64
+	// TotalInodes is the number of inodes on the filesystem. Zero if the filesystem does not report inodes.
+	TotalInodes uint64
+	// FreeInodes is the number of free inodes on the filesystem.
+	FreeInodes uint64
+	// Error describes why the path is not considered healthy. Empty if the path is within all of its thresholds.
+	Error string
+}
+
+// Details contains the usage of every path evaluated by the check function. It implements the optional detail
+// interfaces of the healthhttp package, so the lowest free percentage is reported as the observed value of the IETF
+// encoder.
+type Details struct {
+	// Paths contains the usage of each path, in the order that the paths were provided.
+	Paths []PathDetails
+}
+
+// ObservedValue returns the lowest free percentage of all of the paths.
+func (details Details) ObservedValue() interface{} {
+	var lowest float64
+	for i, path := range details.Paths {
+		if i == 0 || path.FreePercent < lowest {
+			lowest = path.FreePercent
+		}
+	}
+
+	return lowest
+}
+
+// ObservedUnit returns the unit of the free percentage.
+func (details Details) ObservedUnit() string {
+	return "%"
+}
+
+// MeasurementName returns the name of the free percentage measurement.
+func (details Details) MeasurementName() string {
+	return "freeSpace"
+}
+
+// Output returns the reasons that the paths are not considered healthy.
+func (details Details) Output() string {
+	var errs []string
+	for _, path := range details.Paths {
+		if path.Error != "" {
+			errs = append(errs, path.Path+": "+path.Error)
+		}
+	}
+
+	return strings.Join(errs, "; ")
+}
+
+// usage is the usage of a filesystem as reported by the operating system.
+type usage struct {
+	totalBytes     uint64
+	availableBytes uint64
+	totalInodes    uint64
+	freeInodes     uint64
+}
+
+// New creates a health check function that evaluates the filesystems that the provided paths belong to. The check is
+// reported with the most degraded state of all of the paths, and as StateDown if the usage of a path cannot be
+// determined. The status details are always of type Details. Usage is determined with statfs, which is supported on
+// Linux, macOS, and FreeBSD; paths are always reported as StateDown on other platforms. Since statfs cannot be
+// interrupted by the context provided to the check function, set EnforceTimeout on the check when evaluating network
+// filesystems. The return value will never be nil.
+func New(paths ...Path) health.CheckFunc {
+	return func(ctx context.Context) health.Status {
+		details := Details{Paths: make([]PathDetails, 0, len(paths))}
+		state := health.StateUp
+
+		for _, path := range paths {
+			var pathDetails PathDetails
+			if err := ctx.Err(); err != nil {
+				pathDetails = PathDetails{Path: path.Path, State: health.StateDown, Error: err.Error()}
+			} else {
+				pathDetails = evaluate(path)
+			}
+
+			details.Paths = append(details.Paths, pathDetails)
+			if pathDetails.State < state {
+				state = pathDetails.State
+			}
+		}
+
+		return health.Status{State: state, Details: details}
+	}
+}
+
+// evaluate determines the usage of the filesystem that the path belongs to and compares it against the thresholds.
+func evaluate(path Path) PathDetails {
+	details := PathDetails{Path: path.Path, State: health.StateUp}
+
+	usage, err := statfs(path.Path)
+	if err != nil {
+		details.State = health.StateDown
+		details.Error = err.Error()
+		return details
+	}
+
+	details.TotalBytes = usage.totalBytes
+	details.FreeBytes = usage.availableBytes
+	details.TotalInodes = usage.totalInodes
+	details.FreeInodes = usage.freeInodes
+	if usage.totalBytes > 0 {
+		details.FreePercent = float64(usage.availableBytes) / float64(usage.totalBytes) * 100
+	}
+
+	var downs []string
+	var warns []string
+
+	if path.DownFreeBytes > 0 && details.FreeBytes < path.DownFreeBytes {
+		downs = append(downs, fmt.Sprintf("%d bytes free", details.FreeBytes))
+	} else if path.WarnFreeBytes > 0 && details.FreeBytes < path.WarnFreeBytes {
+		warns = append(warns, fmt.Sprintf("%d bytes free", details.FreeBytes))
+	}
+
+	if path.DownFreePercent > 0 && details.FreePercent < path.DownFreePercent {
+		downs = append(downs, fmt.Sprintf("%.1f%% free", details.FreePercent))
+	} else if path.WarnFreePercent > 0 && details.FreePercent < path.WarnFreePercent {
+		warns = append(warns, fmt.Sprintf("%.1f%% free", details.FreePercent))
+	}
+
+	if details.TotalInodes > 0 {
+		if path.DownFreeInodes > 0 && details.FreeInodes < path.DownFreeInodes {
+			downs = append(downs, fmt.Sprintf("%d inodes free", details.FreeInodes))
+		} else if path.WarnFreeInodes > 0 && details.FreeInodes < path.WarnFreeInodes {
+			warns = append(warns, fmt.Sprintf("%d inodes free", details.FreeInodes))
+		}
+	}
+
+	if len(downs) > 0 {
+		details.State = health.StateDown
+		details.Error = strings.Join(append(downs, warns...), ", ")
+	} else if len(warns) > 0 {
+		details.State = health.StateWarn
+		details.Error = strings.Join(warns, ", ")
+	}
+
+	return details
+}
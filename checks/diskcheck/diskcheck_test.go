@@ -0,0 +1,126 @@
+package diskcheck_test
+
+import (
+	"context"
+	"math"
+	"path/filepath"
+	"testing"
+
+	"github.com/jaredpetersen/go-health/checks/diskcheck"
+	"github.com/jaredpetersen/go-health/health"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCheckUp(t *testing.T) {
+	path := t.TempDir()
+
+	status := diskcheck.New(diskcheck.Path{Path: path})(context.Background())
+
+	assert.Equal(t, health.StateUp, status.State)
+	details := status.Details.(diskcheck.Details)
+	if assert.Equal(t, 1, len(details.Paths)) {
+		pathDetails := details.Paths[0]
+		assert.Equal(t, path, pathDetails.Path)
+		assert.Equal(t, health.StateUp, pathDetails.State)
+		assert.True(t, pathDetails.TotalBytes > 0, "Total bytes is not set")
+		assert.True(t, pathDetails.FreeBytes <= pathDetails.TotalBytes, "Free bytes exceeds total bytes")
+		assert.True(t, pathDetails.FreePercent >= 0 && pathDetails.FreePercent <= 100, "Free percent is out of range")
+		assert.Empty(t, pathDetails.Error)
+	}
+	assert.Empty(t, details.Output())
+}
+
+func TestCheckDownFreeBytes(t *testing.T) {
+	path := t.TempDir()
+
+	status := diskcheck.New(diskcheck.Path{
+		Path:          path,
+		WarnFreeBytes: math.MaxUint64,
+		DownFreeBytes: math.MaxUint64,
+	})(context.Background())
+
+	assert.Equal(t, health.StateDown, status.State)
+	pathDetails := status.Details.(diskcheck.Details).Paths[0]
+	assert.Equal(t, health.StateDown, pathDetails.State)
+	assert.Contains(t, pathDetails.Error, "bytes free")
+}
+
+func TestCheckWarnFreePercent(t *testing.T) {
+	path := t.TempDir()
+
+	status := diskcheck.New(diskcheck.Path{Path: path, WarnFreePercent: 101})(context.Background())
+
+	assert.Equal(t, health.StateWarn, status.State)
+	pathDetails := status.Details.(diskcheck.Details).Paths[0]
+	assert.Equal(t, health.StateWarn, pathDetails.State)
+	assert.Contains(t, pathDetails.Error, "% free")
+}
+
+func TestCheckDownFreePercentWithWarnings(t *testing.T) {
+	path := t.TempDir()
+
+	status := diskcheck.New(diskcheck.Path{
+		Path:            path,
+		WarnFreeBytes:   math.MaxUint64,
+		DownFreePercent: 101,
+	})(context.Background())
+
+	assert.Equal(t, health.StateDown, status.State)
+	pathDetails := status.Details.(diskcheck.Details).Paths[0]
+	assert.Contains(t, pathDetails.Error, "% free")
+	assert.Contains(t, pathDetails.Error, "bytes free")
+}
+
+func TestCheckMissingPath(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "missing")
+
+	status := diskcheck.New(diskcheck.Path{Path: path})(context.Background())
+
+	assert.Equal(t, health.StateDown, status.State)
+	pathDetails := status.Details.(diskcheck.Details).Paths[0]
+	assert.Equal(t, health.StateDown, pathDetails.State)
+	assert.NotEmpty(t, pathDetails.Error)
+}
+
+func TestCheckMultiplePaths(t *testing.T) {
+	healthyPath := t.TempDir()
+	warnPath := t.TempDir()
+
+	status := diskcheck.New(
+		diskcheck.Path{Path: healthyPath},
+		diskcheck.Path{Path: warnPath, WarnFreePercent: 101},
+	)(context.Background())
+
+	assert.Equal(t, health.StateWarn, status.State)
+	details := status.Details.(diskcheck.Details)
+	if assert.Equal(t, 2, len(details.Paths)) {
+		assert.Equal(t, healthyPath, details.Paths[0].Path)
+		assert.Equal(t, health.StateUp, details.Paths[0].State)
+		assert.Equal(t, warnPath, details.Paths[1].Path)
+		assert.Equal(t, health.StateWarn, details.Paths[1].State)
+	}
+	assert.Contains(t, details.Output(), warnPath+": ")
+}
+
+func TestCheckContextDone(t *testing.T) {
+	path := t.TempDir()
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	status := diskcheck.New(diskcheck.Path{Path: path})(ctx)
+
+	assert.Equal(t, health.StateDown, status.State)
+	assert.Equal(t, context.Canceled.Error(), status.Details.(diskcheck.Details).Paths[0].Error)
+}
+
+func TestDetails(t *testing.T) {
+	details := diskcheck.Details{Paths: []diskcheck.PathDetails{
+		{Path: "/var/log", FreePercent: 5, Error: "5.0% free"},
+		{Path: "/data", FreePercent: 40},
+	}}
+
+	assert.Equal(t, float64(5), details.ObservedValue())
+	assert.Equal(t, "%", details.ObservedUnit())
+	assert.Equal(t, "freeSpace", details.MeasurementName())
+	assert.Equal(t, "/var/log: 5.0% free", details.Output())
+}
@@ -0,0 +1,43 @@
+package diskcheck_test
+
+import (
+	"context"
+	"time"
+
+	"github.com/jaredpetersen/go-health/checks/diskcheck"
+	"github.com/jaredpetersen/go-health/health"
+)
+
+func Example() {
+	// Create the health monitor that will be polling the resources.
+	healthMonitor := health.New()
+
+	// Prepare the context -- this can be used to stop async monitoring.
+	ctx := context.Background()
+
+	// Create your health checks.
+	diskHealthCheckFunc := diskcheck.New(
+		diskcheck.Path{
+			Path:            "/var/log",
+			WarnFreePercent: 20,
+			DownFreePercent: 5,
+			DownFreeInodes:  1000,
+		},
+		diskcheck.Path{
+			Path:          "/data",
+			WarnFreeBytes: 10 << 30,
+			DownFreeBytes: 1 << 30,
+		},
+	)
+	diskHealthCheck := health.NewCheck("disk", diskHealthCheckFunc)
+	diskHealthCheck.TTL = time.Second * 30
+	diskHealthCheck.Timeout = time.Second
+	diskHealthCheck.EnforceTimeout = true
+	healthMonitor.Monitor(ctx, diskHealthCheck)
+
+	// Wait for all of the checks to execute at least once.
+	healthMonitor.WaitReady(ctx)
+
+	// Retrieve the most recent cached result for all of the checks.
+	healthMonitor.Check()
+}
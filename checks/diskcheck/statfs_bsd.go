@@ -0,0 +1,34 @@
+//go:build darwin || freebsd
+// +build darwin freebsd
+
+package diskcheck
+
+import "syscall"
+
+// statfs returns the usage of the filesystem that the path belongs to.
+func statfs(path string) (usage, error) {
+	var stat syscall.Statfs_t
+	if err := syscall.Statfs(path, &stat); err != nil {
+		return usage{}, err
+	}
+
+	blockSize := uint64(stat.Bsize)
+
+	// Some platforms report the available blocks and free inodes as signed values, which are negative when the
+	// reserved space is in use
+	availableBlocks := int64(stat.Bavail)
+	if availableBlocks < 0 {
+		availableBlocks = 0
+	}
+	freeInodes := int64(stat.Ffree)
+	if freeInodes < 0 {
+		freeInodes = 0
+	}
+
+	return usage{
+		totalBytes:     uint64(stat.Blocks) * blockSize,
+		availableBytes: uint64(availableBlocks) * blockSize,
+		totalInodes:    uint64(stat.Files),
+		freeInodes:     uint64(freeInodes),
+	}, nil
+}
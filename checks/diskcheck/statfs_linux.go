@@ -0,0 +1,24 @@
+package diskcheck
+
+import "syscall"
+
+// statfs returns the usage of the filesystem that the path belongs to.
+func statfs(path string) (usage, error) {
+	var stat syscall.Statfs_t
+	if err := syscall.Statfs(path, &stat); err != nil {
+		return usage{}, err
+	}
+
+	// Block counts are reported in units of the fragment size, which older kernels do not report
+	blockSize := uint64(stat.Frsize)
+	if blockSize == 0 {
+		blockSize = uint64(stat.Bsize)
+	}
+
+	return usage{
+		totalBytes:     stat.Blocks * blockSize,
+		availableBytes: stat.Bavail * blockSize,
+		totalInodes:    stat.Files,
+		freeInodes:     stat.Ffree,
+	}, nil
+}
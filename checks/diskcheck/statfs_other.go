@@ -0,0 +1,11 @@
+//go:build !linux && !darwin && !freebsd
+// +build !linux,!darwin,!freebsd
+
+package diskcheck
+
+import "errors"
+
+// statfs returns an error as filesystem usage is not supported on this platform.
+func statfs(path string) (usage, error) {
+	return usage{}, errors.New("disk usage is not supported on this platform")
+}
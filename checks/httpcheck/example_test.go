@@ -0,0 +1,35 @@
+package httpcheck_test
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"github.com/jaredpetersen/go-health/checks/httpcheck"
+	"github.com/jaredpetersen/go-health/health"
+)
+
+func Example() {
+	// Create the health monitor that will be polling the resources.
+	healthMonitor := health.New()
+
+	// Prepare the context -- this can be used to stop async monitoring.
+	ctx := context.Background()
+
+	// Create your health checks.
+	exampleHealthCheckFunc := httpcheck.New(
+		"https://example.com/health",
+		httpcheck.WithHeader("Accept", "application/json"),
+		httpcheck.WithExpectedStatus(http.StatusOK, http.StatusOK),
+		httpcheck.WithJSONPath("status", "pass"),
+		httpcheck.WithWarnLatency(time.Millisecond*500))
+	exampleHealthCheck := health.NewCheck("example", exampleHealthCheckFunc)
+	exampleHealthCheck.Timeout = time.Second * 2
+	healthMonitor.Monitor(ctx, exampleHealthCheck)
+
+	// Wait for all of the checks to execute at least once.
+	healthMonitor.WaitReady(ctx)
+
+	// Retrieve the most recent cached result for all of the checks.
+	healthMonitor.Check()
+}
@@ -0,0 +1,360 @@
+// Package httpcheck provides a health check function that evaluates an HTTP endpoint.
+package httpcheck
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/jaredpetersen/go-health/health"
+)
+
+// defaultMaxBodySize is the max number of response body bytes that are read unless configured otherwise.
+const defaultMaxBodySize = 1 << 20
+
+// maxRedirects is the max number of redirects that are followed, matching the default behavior of http.Client.
+const maxRedirects = 10
+
+// Details contains information about the HTTP request made by the check function. It implements the optional detail
+// interfaces of the healthhttp package, so the latency is reported as the observed value of the IETF encoder.
+type Details struct {
+	// URL is the URL that was requested.
+	URL string
+	// StatusCode is the status code of the response. Zero if no response was received.
+	StatusCode int
+	// Latency is the time between sending the request and receiving the response headers.
+	Latency time.Duration
+	// Bytes is the number of response body bytes that were read.
+	Bytes int64
+	// Redirects contains the URLs that were redirected to, in order.
+	Redirects []string
+	// Error describes why the endpoint is not considered healthy. Empty if the request passed all of the assertions.
+	Error string
+}
+
+// ObservedValue returns the latency in milliseconds.
+func (details Details) ObservedValue() interface{} {
+	return details.Latency.Milliseconds()
+}
+
+// ObservedUnit returns the unit of the latency.
+func (details Details) ObservedUnit() string {
+	return "ms"
+}
+
+// MeasurementName returns the name of the latency measurement.
+func (details Details) MeasurementName() string {
+	return "responseTime"
+}
+
+// Output returns the reason that the endpoint is not considered healthy.
+func (details Details) Output() string {
+	return details.Error
+}
+
+// statusRange is an inclusive range of acceptable response status codes.
+type statusRange struct {
+	min int
+	max int
+}
+
+// bodyAssertion evaluates the response body, returning an error describing the failure if the body is not acceptable.
+type bodyAssertion func(body []byte) error
+
+// checker evaluates an HTTP endpoint.
+type checker struct {
+	// url is the URL that is requested.
+	url string
+	// method is the HTTP method of the request.
+	method string
+	// header contains the headers sent with the request.
+	header http.Header
+	// client sends the request.
+	client *http.Client
+	// tlsConfig is the TLS configuration of the client's transport. Nil if the transport is not customized.
+	tlsConfig *tls.Config
+	// statusRanges contains the acceptable response status codes.
+	statusRanges []statusRange
+	// bodyAssertions contains the assertions that the response body must pass.
+	bodyAssertions []bodyAssertion
+	// warnLatency is the latency above which the endpoint is reported as StateWarn. Zero if disabled.
+	warnLatency time.Duration
+	// maxBodySize is the max number of response body bytes that are read.
+	maxBodySize int64
+}
+
+// Option is used to configure optional check behavior.
+type Option func(checker *checker)
+
+// WithMethod configures the HTTP method of the request. Defaults to GET.
+func WithMethod(method string) Option {
+	return func(checker *checker) {
+		checker.method = method
+	}
+}
+
+// WithHeader adds a header to the request. May be provided multiple times, including for the same key.
+func WithHeader(key string, value string) Option {
+	return func(checker *checker) {
+		checker.header.Add(key, value)
+	}
+}
+
+// WithClient configures the HTTP client used to send the request. Defaults to a new client with the default transport.
+// The client's redirect policy is respected.
+func WithClient(client *http.Client) Option {
+	return func(checker *checker) {
+		checker.client = client
+	}
+}
+
+// WithTLSConfig configures the TLS settings used to connect to the endpoint, e.g. to trust a private certificate
+// authority or to present a client certificate. A clone of the default transport is used with the provided
+// configuration unless a client with a custom *http.Transport is configured, in which case a clone of that transport is
+// used instead.
+func WithTLSConfig(config *tls.Config) Option {
+	return func(checker *checker) {
+		checker.tlsConfig = config
+	}
+}
+
+// WithExpectedStatus adds an inclusive range of acceptable response status codes. May be provided multiple times.
+// Defaults to any 2xx status code; providing a range replaces the default.
+func WithExpectedStatus(min int, max int) Option {
+	return func(checker *checker) {
+		checker.statusRanges = append(checker.statusRanges, statusRange{min: min, max: max})
+	}
+}
+
+// WithBodyContains requires the response body to contain the provided substring.
+func WithBodyContains(substring string) Option {
+	return func(checker *checker) {
+		checker.bodyAssertions = append(checker.bodyAssertions, func(body []byte) error {
+			if !bytes.Contains(body, []byte(substring)) {
+				return fmt.Errorf("body does not contain %q", substring)
+			}
+			return nil
+		})
+	}
+}
+
+// WithBodyMatches requires the response body to match the provided regular expression.
+func WithBodyMatches(pattern *regexp.Regexp) Option {
+	return func(checker *checker) {
+		checker.bodyAssertions = append(checker.bodyAssertions, func(body []byte) error {
+			if !pattern.Match(body) {
+				return fmt.Errorf("body does not match %q", pattern.String())
+			}
+			return nil
+		})
+	}
+}
+
+// WithJSONPath requires the response body to be JSON with the provided value at the provided path. The path consists
+// of object keys and array indexes separated by dots, e.g. "status" or "components.0.state". The value found at the
+// path is formatted as a string before it is compared, so numbers and booleans are expected as they appear in JSON,
+// e.g. "42" or "true".
+func WithJSONPath(path string, expected string) Option {
+	return func(checker *checker) {
+		checker.bodyAssertions = append(checker.bodyAssertions, func(body []byte) error {
+			actual, err := lookupJSONPath(body, path)
+			if err != nil {
+				return err
+			}
+			if actual != expected {
+				return fmt.Errorf("json path %q is %q, expected %q", path, actual, expected)
+			}
+			return nil
+		})
+	}
+}
+
+// WithWarnLatency configures the latency above which an otherwise healthy endpoint is reported as StateWarn.
+func WithWarnLatency(threshold time.Duration) Option {
+	return func(checker *checker) {
+		checker.warnLatency = threshold
+	}
+}
+
+// WithMaxBodySize configures the max number of response body bytes that are evaluated by the body assertions. The
+// remainder of the body is discarded so that the connection may be reused. Defaults to 1 MiB.
+func WithMaxBodySize(size int64) Option {
+	return func(checker *checker) {
+		checker.maxBodySize = size
+	}
+}
+
+// New creates a health check function that requests the provided URL. By default, the endpoint is reported as
+// StateUp if it responds to a GET request with a 2xx status code and StateDown otherwise. The status details are
+// always of type Details. The request is terminated when the context provided to the check function is done, so
+// configure a timeout on the check. The return value will never be nil.
+func New(url string, opts ...Option) health.CheckFunc {
+	checker := &checker{
+		url:         url,
+		method:      http.MethodGet,
+		header:      make(http.Header),
+		client:      &http.Client{},
+		maxBodySize: defaultMaxBodySize,
+	}
+
+	for _, opt := range opts {
+		opt(checker)
+	}
+
+	if len(checker.statusRanges) == 0 {
+		checker.statusRanges = []statusRange{{min: 200, max: 299}}
+	}
+
+	if checker.tlsConfig != nil {
+		checker.client = withTLSConfig(checker.client, checker.tlsConfig)
+	}
+
+	return checker.check
+}
+
+// withTLSConfig returns a copy of the client whose transport uses the provided TLS configuration.
+func withTLSConfig(client *http.Client, config *tls.Config) *http.Client {
+	transport, ok := client.Transport.(*http.Transport)
+	if !ok || transport == nil {
+		transport = http.DefaultTransport.(*http.Transport)
+	}
+
+	transport = transport.Clone()
+	transport.TLSClientConfig = config
+
+	clientCopy := *client
+	clientCopy.Transport = transport
+
+	return &clientCopy
+}
+
+// check requests the endpoint and evaluates the response.
+func (checker *checker) check(ctx context.Context) health.Status {
+	details := Details{URL: checker.url}
+
+	req, err := http.NewRequestWithContext(ctx, checker.method, checker.url, nil)
+	if err != nil {
+		return down(details, err)
+	}
+	req.Header = checker.header.Clone()
+
+	// Copy the client so that the redirects of this request can be recorded
+	client := *checker.client
+	client.CheckRedirect = func(req *http.Request, via []*http.Request) error {
+		details.Redirects = append(details.Redirects, req.URL.String())
+		if checker.client.CheckRedirect != nil {
+			return checker.client.CheckRedirect(req, via)
+		}
+		if len(via) >= maxRedirects {
+			return fmt.Errorf("stopped after %d redirects", maxRedirects)
+		}
+		return nil
+	}
+
+	start := time.Now()
+	res, err := client.Do(req)
+	details.Latency = time.Since(start)
+	if err != nil {
+		return down(details, err)
+	}
+	defer res.Body.Close()
+
+	details.StatusCode = res.StatusCode
+
+	var body bytes.Buffer
+	details.Bytes, err = io.Copy(&body, io.LimitReader(res.Body, checker.maxBodySize))
+	if err != nil {
+		return down(details, fmt.Errorf("failed to read body: %w", err))
+	}
+
+	// Drain the remainder of the body so that the connection may be reused
+	_, _ = io.Copy(io.Discard, res.Body)
+
+	if !checker.acceptableStatus(res.StatusCode) {
+		return down(details, fmt.Errorf("unexpected status code %d", res.StatusCode))
+	}
+
+	for _, assertion := range checker.bodyAssertions {
+		if err := assertion(body.Bytes()); err != nil {
+			return down(details, err)
+		}
+	}
+
+	if checker.warnLatency > 0 && details.Latency > checker.warnLatency {
+		details.Error = fmt.Sprintf("latency exceeded %s", checker.warnLatency)
+		return health.Status{State: health.StateWarn, Details: details}
+	}
+
+	return health.Status{State: health.StateUp, Details: details}
+}
+
+// acceptableStatus determines if the status code is within any of the expected ranges.
+func (checker *checker) acceptableStatus(statusCode int) bool {
+	for _, statusRange := range checker.statusRanges {
+		if statusCode >= statusRange.min && statusCode <= statusRange.max {
+			return true
+		}
+	}
+
+	return false
+}
+
+// down creates a StateDown status with the provided error recorded in the details.
+func down(details Details, err error) health.Status {
+	details.Error = err.Error()
+	return health.Status{State: health.StateDown, Details: details}
+}
+
+// lookupJSONPath decodes the JSON document and returns the value found at the path formatted as a string.
+func lookupJSONPath(document []byte, path string) (string, error) {
+	var value interface{}
+	decoder := json.NewDecoder(bytes.NewReader(document))
+	decoder.UseNumber()
+	if err := decoder.Decode(&value); err != nil {
+		return "", fmt.Errorf("body is not valid json: %w", err)
+	}
+
+	if path != "" {
+		for _, segment := range strings.Split(path, ".") {
+			switch node := value.(type) {
+			case map[string]interface{}:
+				child, ok := node[segment]
+				if !ok {
+					return "", fmt.Errorf("json path %q not found", path)
+				}
+				value = child
+			case []interface{}:
+				index, err := strconv.Atoi(segment)
+				if err != nil || index < 0 || index >= len(node) {
+					return "", fmt.Errorf("json path %q not found", path)
+				}
+				value = node[index]
+			default:
+				return "", fmt.Errorf("json path %q not found", path)
+			}
+		}
+	}
+
+	if value == nil {
+		return "null", nil
+	}
+
+	switch value.(type) {
+	case map[string]interface{}, []interface{}:
+		encoded, err := json.Marshal(value)
+		if err != nil {
+			return "", err
+		}
+		return string(encoded), nil
+	default:
+		return fmt.Sprint(value), nil
+	}
+}
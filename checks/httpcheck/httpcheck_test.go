@@ -0,0 +1,246 @@
+package httpcheck_test
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/jaredpetersen/go-health/checks/httpcheck"
+	"github.com/jaredpetersen/go-health/health"
+	"github.com/stretchr/testify/assert"
+)
+
+// newServer creates a test server that responds with the provided status code and body.
+func newServer(t *testing.T, statusCode int, body string) *httptest.Server {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(statusCode)
+		fmt.Fprint(w, body)
+	}))
+	t.Cleanup(server.Close)
+
+	return server
+}
+
+func TestCheckUp(t *testing.T) {
+	server := newServer(t, http.StatusOK, "ok")
+
+	status := httpcheck.New(server.URL)(context.Background())
+
+	assert.Equal(t, health.StateUp, status.State)
+	details := status.Details.(httpcheck.Details)
+	assert.Equal(t, server.URL, details.URL)
+	assert.Equal(t, http.StatusOK, details.StatusCode)
+	assert.Equal(t, int64(2), details.Bytes)
+	assert.True(t, details.Latency > 0, "Latency is not set")
+	assert.Empty(t, details.Error)
+}
+
+func TestCheckUnexpectedStatus(t *testing.T) {
+	server := newServer(t, http.StatusInternalServerError, "")
+
+	status := httpcheck.New(server.URL)(context.Background())
+
+	assert.Equal(t, health.StateDown, status.State)
+	details := status.Details.(httpcheck.Details)
+	assert.Equal(t, http.StatusInternalServerError, details.StatusCode)
+	assert.Equal(t, "unexpected status code 500", details.Error)
+}
+
+func TestCheckExpectedStatus(t *testing.T) {
+	server := newServer(t, http.StatusTooManyRequests, "")
+
+	checkFunc := httpcheck.New(server.URL,
+		httpcheck.WithExpectedStatus(200, 299),
+		httpcheck.WithExpectedStatus(429, 429))
+	status := checkFunc(context.Background())
+
+	assert.Equal(t, health.StateUp, status.State)
+}
+
+func TestCheckMethodAndHeaders(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodHead || r.Header.Get("Authorization") != "Bearer token" {
+			w.WriteHeader(http.StatusBadRequest)
+		}
+	}))
+	defer server.Close()
+
+	checkFunc := httpcheck.New(server.URL,
+		httpcheck.WithMethod(http.MethodHead),
+		httpcheck.WithHeader("Authorization", "Bearer token"))
+	status := checkFunc(context.Background())
+
+	assert.Equal(t, health.StateUp, status.State)
+}
+
+func TestCheckBodyAssertions(t *testing.T) {
+	server := newServer(t, http.StatusOK, `{"status":"pass","components":[{"name":"db","ready":true,"connections":5}]}`)
+
+	tests := []struct {
+		name          string
+		option        httpcheck.Option
+		expectedState health.State
+		expectedError string
+	}{
+		{"contains", httpcheck.WithBodyContains(`"pass"`), health.StateUp, ""},
+		{"not contains", httpcheck.WithBodyContains("fail"), health.StateDown, `body does not contain "fail"`},
+		{"matches", httpcheck.WithBodyMatches(regexp.MustCompile(`"status":"\w+"`)), health.StateUp, ""},
+		{"not matches", httpcheck.WithBodyMatches(regexp.MustCompile(`^fail`)), health.StateDown,
+			`body does not match "^fail"`},
+		{"json string", httpcheck.WithJSONPath("status", "pass"), health.StateUp, ""},
+		{"json bool", httpcheck.WithJSONPath("components.0.ready", "true"), health.StateUp, ""},
+		{"json number", httpcheck.WithJSONPath("components.0.connections", "5"), health.StateUp, ""},
+		{"json mismatch", httpcheck.WithJSONPath("status", "fail"), health.StateDown,
+			`json path "status" is "pass", expected "fail"`},
+		{"json missing", httpcheck.WithJSONPath("components.1.name", "db"), health.StateDown,
+			`json path "components.1.name" not found`},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			status := httpcheck.New(server.URL, test.option)(context.Background())
+
+			assert.Equal(t, test.expectedState, status.State)
+			assert.Equal(t, test.expectedError, status.Details.(httpcheck.Details).Error)
+		})
+	}
+}
+
+func TestCheckJSONPathInvalidBody(t *testing.T) {
+	server := newServer(t, http.StatusOK, "not json")
+
+	status := httpcheck.New(server.URL, httpcheck.WithJSONPath("status", "pass"))(context.Background())
+
+	assert.Equal(t, health.StateDown, status.State)
+	assert.Contains(t, status.Details.(httpcheck.Details).Error, "body is not valid json")
+}
+
+func TestCheckMaxBodySize(t *testing.T) {
+	server := newServer(t, http.StatusOK, "0123456789")
+
+	status := httpcheck.New(server.URL,
+		httpcheck.WithMaxBodySize(5),
+		httpcheck.WithBodyContains("9"))(context.Background())
+
+	assert.Equal(t, health.StateDown, status.State)
+	assert.Equal(t, int64(5), status.Details.(httpcheck.Details).Bytes)
+}
+
+func TestCheckWarnLatency(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		time.Sleep(time.Millisecond * 20)
+	}))
+	defer server.Close()
+
+	status := httpcheck.New(server.URL, httpcheck.WithWarnLatency(time.Millisecond*10))(context.Background())
+
+	assert.Equal(t, health.StateWarn, status.State)
+	assert.Equal(t, "latency exceeded 10ms", status.Details.(httpcheck.Details).Error)
+}
+
+func TestCheckRedirects(t *testing.T) {
+	mux := http.NewServeMux()
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	mux.HandleFunc("/a", func(w http.ResponseWriter, r *http.Request) {
+		http.Redirect(w, r, "/b", http.StatusFound)
+	})
+	mux.HandleFunc("/b", func(w http.ResponseWriter, r *http.Request) {
+		http.Redirect(w, r, "/c", http.StatusFound)
+	})
+	mux.HandleFunc("/c", func(w http.ResponseWriter, r *http.Request) {})
+
+	status := httpcheck.New(server.URL + "/a")(context.Background())
+
+	assert.Equal(t, health.StateUp, status.State)
+	assert.Equal(t, []string{server.URL + "/b", server.URL + "/c"}, status.Details.(httpcheck.Details).Redirects)
+}
+
+func TestCheckTooManyRedirects(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Redirect(w, r, r.URL.Path, http.StatusFound)
+	}))
+	defer server.Close()
+
+	status := httpcheck.New(server.URL + "/a")(context.Background())
+
+	assert.Equal(t, health.StateDown, status.State)
+	assert.Contains(t, status.Details.(httpcheck.Details).Error, "stopped after 10 redirects")
+	assert.Equal(t, 10, len(status.Details.(httpcheck.Details).Redirects))
+}
+
+func TestCheckClientRedirectPolicy(t *testing.T) {
+	mux := http.NewServeMux()
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	mux.HandleFunc("/a", func(w http.ResponseWriter, r *http.Request) {
+		http.Redirect(w, r, "/b", http.StatusFound)
+	})
+
+	client := &http.Client{
+		CheckRedirect: func(req *http.Request, via []*http.Request) error {
+			return http.ErrUseLastResponse
+		},
+	}
+	status := httpcheck.New(server.URL+"/a",
+		httpcheck.WithClient(client),
+		httpcheck.WithExpectedStatus(http.StatusFound, http.StatusFound))(context.Background())
+
+	assert.Equal(t, health.StateUp, status.State)
+	assert.Equal(t, []string{server.URL + "/b"}, status.Details.(httpcheck.Details).Redirects)
+}
+
+func TestCheckTLSConfig(t *testing.T) {
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	defer server.Close()
+
+	// The test server certificate is not trusted by default
+	status := httpcheck.New(server.URL)(context.Background())
+	assert.Equal(t, health.StateDown, status.State)
+
+	certPool := x509.NewCertPool()
+	certPool.AddCert(server.Certificate())
+	status = httpcheck.New(server.URL, httpcheck.WithTLSConfig(&tls.Config{RootCAs: certPool}))(context.Background())
+	assert.Equal(t, health.StateUp, status.State)
+}
+
+func TestCheckContextDone(t *testing.T) {
+	unblock := make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		<-unblock
+	}))
+	defer server.Close()
+	defer close(unblock)
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Millisecond*20)
+	defer cancel()
+
+	status := httpcheck.New(server.URL)(ctx)
+
+	assert.Equal(t, health.StateDown, status.State)
+	assert.Contains(t, status.Details.(httpcheck.Details).Error, "context deadline exceeded")
+}
+
+func TestCheckInvalidURL(t *testing.T) {
+	status := httpcheck.New("://invalid")(context.Background())
+
+	assert.Equal(t, health.StateDown, status.State)
+	assert.NotEmpty(t, status.Details.(httpcheck.Details).Error)
+}
+
+func TestDetails(t *testing.T) {
+	details := httpcheck.Details{Latency: time.Millisecond * 250, Error: "unexpected status code 500"}
+
+	assert.Equal(t, int64(250), details.ObservedValue())
+	assert.Equal(t, "ms", details.ObservedUnit())
+	assert.Equal(t, "responseTime", details.MeasurementName())
+	assert.Equal(t, "unexpected status code 500", details.Output())
+}
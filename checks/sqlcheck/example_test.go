@@ -0,0 +1,40 @@
+package sqlcheck_test
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/jaredpetersen/go-health/checks/sqlcheck"
+	"github.com/jaredpetersen/go-health/health"
+)
+
+func Example() {
+	// Create the health monitor that will be polling the resources.
+	healthMonitor := health.New()
+
+	// Prepare the context -- this can be used to stop async monitoring.
+	ctx := context.Background()
+
+	// Open the database with the driver of your choice.
+	db, err := sql.Open("postgres", "postgres://localhost:5432/app")
+	if err != nil {
+		return
+	}
+
+	// Create your health checks.
+	dbHealthCheckFunc := sqlcheck.New(
+		db,
+		sqlcheck.WithValidationQuery("SELECT 1"),
+		sqlcheck.WithWarnSaturation(0.9),
+		sqlcheck.WithWarnWaitDuration(time.Second))
+	dbHealthCheck := health.NewCheck("db", dbHealthCheckFunc)
+	dbHealthCheck.Timeout = time.Second * 2
+	healthMonitor.Monitor(ctx, dbHealthCheck)
+
+	// Wait for all of the checks to execute at least once.
+	healthMonitor.WaitReady(ctx)
+
+	// Retrieve the most recent cached result for all of the checks.
+	healthMonitor.Check()
+}
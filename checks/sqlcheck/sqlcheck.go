@@ -0,0 +1,177 @@
+// Package sqlcheck provides a health check function that evaluates a database through database/sql.
+package sqlcheck
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/jaredpetersen/go-health/health"
+)
+
+// Details contains information about the database and its connection pool. It implements the optional detail
+// interfaces of the healthhttp package, so the latency is reported as the observed value of the IETF encoder.
+type Details struct {
+	// Latency is the time it took to ping the database and run the validation query, if any.
+	Latency time.Duration
+	// Stats contains the connection pool statistics as of the end of the check.
+	Stats sql.DBStats
+	// Saturation is the fraction of the max open connections that are in use. Zero if the max open connections is not
+	// limited.
+	Saturation float64
+	// WaitDuration is the time spent waiting on a connection since the previous execution of the check function.
+	WaitDuration time.Duration
+	// Error describes why the database is not considered healthy. Empty if the database passed all of the assertions.
+	Error string
+}
+
+// ObservedValue returns the latency in milliseconds.
+func (details Details) ObservedValue() interface{} {
+	return details.Latency.Milliseconds()
+}
+
+// ObservedUnit returns the unit of the latency.
+func (details Details) ObservedUnit() string {
+	return "ms"
+}
+
+// MeasurementName returns the name of the latency measurement.
+func (details Details) MeasurementName() string {
+	return "responseTime"
+}
+
+// Output returns the reason that the database is not considered healthy.
+func (details Details) Output() string {
+	return details.Error
+}
+
+// checker evaluates a database.
+type checker struct {
+	// db is the database being evaluated.
+	db *sql.DB
+	// validationQuery is run after the ping. Empty if no query is run.
+	validationQuery string
+	// warnSaturation is the saturation at or above which the database is reported as StateWarn. Zero if disabled.
+	warnSaturation float64
+	// warnWaitDuration is the wait duration above which the database is reported as StateWarn. Zero if disabled.
+	warnWaitDuration time.Duration
+	// previousWaitDuration is the total wait duration of the pool as of the previous execution.
+	previousWaitDuration time.Duration
+	// mtx coordinates access to the previous wait duration in case executions overlap.
+	mtx sync.Mutex
+}
+
+// Option is used to configure optional check behavior.
+type Option func(checker *checker)
+
+// WithValidationQuery configures a query that is run after the database has been pinged, e.g. "SELECT 1". Any rows
+// returned by the query are discarded.
+func WithValidationQuery(query string) Option {
+	return func(checker *checker) {
+		checker.validationQuery = query
+	}
+}
+
+// WithWarnSaturation configures the fraction (0 to 1) of the max open connections that may be in use before the
+// database is reported as StateWarn, e.g. 0.9. Has no effect if the max open connections of the database is not
+// limited.
+func WithWarnSaturation(fraction float64) Option {
+	return func(checker *checker) {
+		checker.warnSaturation = fraction
+	}
+}
+
+// WithWarnWaitDuration configures the time that may be spent waiting on a connection from the pool between executions
+// of the check function before the database is reported as StateWarn.
+func WithWarnWaitDuration(threshold time.Duration) Option {
+	return func(checker *checker) {
+		checker.warnWaitDuration = threshold
+	}
+}
+
+// New creates a health check function that pings the provided database. By default, the database is reported as
+// StateUp if the ping succeeds and StateDown otherwise. The status details are always of type Details. The ping and
+// validation query are terminated when the context provided to the check function is done, so configure a timeout on
+// the check. The return value will never be nil.
+func New(db *sql.DB, opts ...Option) health.CheckFunc {
+	checker := &checker{
+		db: db,
+	}
+
+	for _, opt := range opts {
+		opt(checker)
+	}
+
+	checker.previousWaitDuration = db.Stats().WaitDuration
+
+	return checker.check
+}
+
+// check pings the database and evaluates the connection pool.
+func (checker *checker) check(ctx context.Context) health.Status {
+	var details Details
+
+	start := time.Now()
+	err := checker.validate(ctx)
+	details.Latency = time.Since(start)
+
+	details.Stats = checker.db.Stats()
+	if details.Stats.MaxOpenConnections > 0 {
+		details.Saturation = float64(details.Stats.InUse) / float64(details.Stats.MaxOpenConnections)
+	}
+
+	checker.mtx.Lock()
+	details.WaitDuration = details.Stats.WaitDuration - checker.previousWaitDuration
+	checker.previousWaitDuration = details.Stats.WaitDuration
+	checker.mtx.Unlock()
+
+	if err != nil {
+		details.Error = err.Error()
+		return health.Status{State: health.StateDown, Details: details}
+	}
+
+	var warnings []string
+	if checker.warnSaturation > 0 && details.Saturation >= checker.warnSaturation {
+		warnings = append(warnings, fmt.Sprintf("%d of %d connections in use", details.Stats.InUse,
+			details.Stats.MaxOpenConnections))
+	}
+	if checker.warnWaitDuration > 0 && details.WaitDuration > checker.warnWaitDuration {
+		warnings = append(warnings, fmt.Sprintf("waited %s for connections", details.WaitDuration))
+	}
+
+	if len(warnings) > 0 {
+		details.Error = strings.Join(warnings, ", ")
+		return health.Status{State: health.StateWarn, Details: details}
+	}
+
+	return health.Status{State: health.StateUp, Details: details}
+}
+
+// validate pings the database and runs the validation query, if any.
+func (checker *checker) validate(ctx context.Context) error {
+	if err := checker.db.PingContext(ctx); err != nil {
+		return fmt.Errorf("failed to ping: %w", err)
+	}
+
+	if checker.validationQuery == "" {
+		return nil
+	}
+
+	rows, err := checker.db.QueryContext(ctx, checker.validationQuery)
+	if err != nil {
+		return fmt.Errorf("failed to run validation query: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		// Discard the rows
+	}
+	if err := rows.Err(); err != nil {
+		return fmt.Errorf("failed to run validation query: %w", err)
+	}
+
+	return nil
+}
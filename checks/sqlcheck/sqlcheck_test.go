@@ -0,0 +1,202 @@
+package sqlcheck_test
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"io"
+	"testing"
+	"time"
+
+	"github.com/jaredpetersen/go-health/checks/sqlcheck"
+	"github.com/jaredpetersen/go-health/health"
+	"github.com/stretchr/testify/assert"
+)
+
+// fakeConnector is a driver.Connector for a fake database that fails pings and queries with the configured errors.
+type fakeConnector struct {
+	pingErr  error
+	queryErr error
+	queries  chan string
+}
+
+func (connector *fakeConnector) Connect(ctx context.Context) (driver.Conn, error) {
+	return &fakeConn{connector: connector}, nil
+}
+
+func (connector *fakeConnector) Driver() driver.Driver {
+	return fakeDriver{}
+}
+
+// fakeDriver is a driver.Driver that cannot be opened by name.
+type fakeDriver struct{}
+
+func (d fakeDriver) Open(name string) (driver.Conn, error) {
+	return nil, errors.New("not supported")
+}
+
+// fakeConn is a connection to the fake database.
+type fakeConn struct {
+	connector *fakeConnector
+}
+
+func (conn *fakeConn) Prepare(query string) (driver.Stmt, error) {
+	return nil, errors.New("not supported")
+}
+
+func (conn *fakeConn) Close() error {
+	return nil
+}
+
+func (conn *fakeConn) Begin() (driver.Tx, error) {
+	return nil, errors.New("not supported")
+}
+
+func (conn *fakeConn) Ping(ctx context.Context) error {
+	return conn.connector.pingErr
+}
+
+func (conn *fakeConn) QueryContext(ctx context.Context, query string, args []driver.NamedValue) (driver.Rows, error) {
+	if conn.connector.queries != nil {
+		conn.connector.queries <- query
+	}
+	if conn.connector.queryErr != nil {
+		return nil, conn.connector.queryErr
+	}
+	return &fakeRows{remaining: 1}, nil
+}
+
+// fakeRows is the result of a query against the fake database.
+type fakeRows struct {
+	remaining int
+}
+
+func (rows *fakeRows) Columns() []string {
+	return []string{"1"}
+}
+
+func (rows *fakeRows) Close() error {
+	return nil
+}
+
+func (rows *fakeRows) Next(dest []driver.Value) error {
+	if rows.remaining == 0 {
+		return io.EOF
+	}
+	rows.remaining--
+	dest[0] = int64(1)
+	return nil
+}
+
+// newDB creates a database handle for the fake database.
+func newDB(t *testing.T, connector *fakeConnector) *sql.DB {
+	db := sql.OpenDB(connector)
+	t.Cleanup(func() { db.Close() })
+
+	return db
+}
+
+func TestCheckUp(t *testing.T) {
+	db := newDB(t, &fakeConnector{})
+
+	status := sqlcheck.New(db)(context.Background())
+
+	assert.Equal(t, health.StateUp, status.State)
+	details := status.Details.(sqlcheck.Details)
+	assert.Equal(t, 1, details.Stats.OpenConnections)
+	assert.Equal(t, 1, details.Stats.Idle)
+	assert.Equal(t, 0, details.Stats.InUse)
+	assert.True(t, details.Latency > 0, "Latency is not set")
+	assert.Empty(t, details.Error)
+}
+
+func TestCheckPingFailure(t *testing.T) {
+	db := newDB(t, &fakeConnector{pingErr: errors.New("connection reset")})
+
+	status := sqlcheck.New(db)(context.Background())
+
+	assert.Equal(t, health.StateDown, status.State)
+	assert.Equal(t, "failed to ping: connection reset", status.Details.(sqlcheck.Details).Error)
+}
+
+func TestCheckValidationQuery(t *testing.T) {
+	connector := &fakeConnector{queries: make(chan string, 1)}
+	db := newDB(t, connector)
+
+	status := sqlcheck.New(db, sqlcheck.WithValidationQuery("SELECT 1"))(context.Background())
+
+	assert.Equal(t, health.StateUp, status.State)
+	assert.Equal(t, "SELECT 1", <-connector.queries)
+}
+
+func TestCheckValidationQueryFailure(t *testing.T) {
+	db := newDB(t, &fakeConnector{queryErr: errors.New("read-only transaction")})
+
+	status := sqlcheck.New(db, sqlcheck.WithValidationQuery("SELECT 1"))(context.Background())
+
+	assert.Equal(t, health.StateDown, status.State)
+	assert.Equal(t, "failed to run validation query: read-only transaction", status.Details.(sqlcheck.Details).Error)
+}
+
+func TestCheckWarnSaturation(t *testing.T) {
+	db := newDB(t, &fakeConnector{})
+	db.SetMaxOpenConns(2)
+	ctx := context.Background()
+
+	conn, err := db.Conn(ctx)
+	assert.NoError(t, err)
+	defer conn.Close()
+
+	status := sqlcheck.New(db, sqlcheck.WithWarnSaturation(0.5))(ctx)
+
+	assert.Equal(t, health.StateWarn, status.State)
+	details := status.Details.(sqlcheck.Details)
+	assert.Equal(t, 0.5, details.Saturation)
+	assert.Equal(t, "1 of 2 connections in use", details.Error)
+}
+
+func TestCheckWarnWaitDuration(t *testing.T) {
+	db := newDB(t, &fakeConnector{})
+	db.SetMaxOpenConns(1)
+	ctx := context.Background()
+
+	checkFunc := sqlcheck.New(db, sqlcheck.WithWarnWaitDuration(time.Millisecond*10))
+
+	// Hold the only connection so that another caller has to wait on it
+	conn, err := db.Conn(ctx)
+	assert.NoError(t, err)
+
+	waited := make(chan struct{})
+	go func() {
+		defer close(waited)
+		waitingConn, err := db.Conn(ctx)
+		if err == nil {
+			waitingConn.Close()
+		}
+	}()
+
+	time.Sleep(time.Millisecond * 20)
+	conn.Close()
+	<-waited
+
+	status := checkFunc(ctx)
+	assert.Equal(t, health.StateWarn, status.State)
+	details := status.Details.(sqlcheck.Details)
+	assert.True(t, details.WaitDuration >= time.Millisecond*10, "Wait duration is too short")
+	assert.Equal(t, int64(1), details.Stats.WaitCount)
+
+	// Only the time spent waiting since the previous execution is considered
+	status = checkFunc(ctx)
+	assert.Equal(t, health.StateUp, status.State)
+	assert.Equal(t, time.Duration(0), status.Details.(sqlcheck.Details).WaitDuration)
+}
+
+func TestDetails(t *testing.T) {
+	details := sqlcheck.Details{Latency: time.Millisecond * 3, Error: "failed to ping: connection reset"}
+
+	assert.Equal(t, int64(3), details.ObservedValue())
+	assert.Equal(t, "ms", details.ObservedUnit())
+	assert.Equal(t, "responseTime", details.MeasurementName())
+	assert.Equal(t, "failed to ping: connection reset", details.Output())
+}
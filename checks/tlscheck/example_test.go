@@ -0,0 +1,35 @@
+package tlscheck_test
+
+import (
+	"context"
+	"time"
+
+	"github.com/jaredpetersen/go-health/checks/tlscheck"
+	"github.com/jaredpetersen/go-health/health"
+)
+
+func Example() {
+	// Create the health monitor that will be polling the resources.
+	healthMonitor := health.New()
+
+	// Prepare the context -- this can be used to stop async monitoring.
+	ctx := context.Background()
+
+	// Create your health checks.
+	apiCertHealthCheckFunc := tlscheck.New("api.example.com:443", tlscheck.WithWarnWindow(time.Hour*24*14))
+	apiCertHealthCheck := health.NewCheck("api-cert", apiCertHealthCheckFunc)
+	apiCertHealthCheck.TTL = time.Hour
+	apiCertHealthCheck.Timeout = time.Second * 5
+	healthMonitor.Monitor(ctx, apiCertHealthCheck)
+
+	servingCertHealthCheckFunc := tlscheck.NewFromFile("/etc/tls/tls.crt")
+	servingCertHealthCheck := health.NewCheck("serving-cert", servingCertHealthCheckFunc)
+	servingCertHealthCheck.TTL = time.Hour
+	healthMonitor.Monitor(ctx, servingCertHealthCheck)
+
+	// Wait for all of the checks to execute at least once.
+	healthMonitor.WaitReady(ctx)
+
+	// Retrieve the most recent cached result for all of the checks.
+	healthMonitor.Check()
+}
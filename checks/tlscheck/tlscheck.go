@@ -0,0 +1,332 @@
+// Package tlscheck provides health check functions that evaluate the expiry of TLS certificates.
+package tlscheck
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"net"
+	"os"
+	"time"
+
+	"github.com/jaredpetersen/go-health/health"
+)
+
+// defaultWarnWindow is the time before expiry that certificates are reported as StateWarn unless configured otherwise.
+const defaultWarnWindow = time.Hour * 24 * 30
+
+// Certificate contains information about a single certificate in the chain.
+type Certificate struct {
+	// Subject is the distinguished name of the certificate subject.
+	Subject string
+	// Issuer is the distinguished name of the certificate issuer.
+	Issuer string
+	// NotBefore is the time that the certificate becomes valid.
+	NotBefore time.Time
+	// NotAfter is the time that the certificate expires.
+	NotAfter time.Time
+}
+
+// Details contains information about the certificate chain evaluated by the check function. It implements the optional
+// detail interfaces of the healthhttp package, so the time until the first certificate in the chain expires is reported
+// as the observed value of the IETF encoder.
+type Details struct {
+	// Source is the address that was connected to or the file that was read. Empty for certificates provided directly.
+	Source string
+	// Certificates contains the certificates in the chain, starting with the leaf.
+	Certificates []Certificate
+	// ExpiresIn is the time until the first certificate in the chain expires. Negative if it has already expired.
+	ExpiresIn time.Duration
+	// Error describes why the certificates are not considered healthy. Empty if the certificates passed all of the
+	// assertions.
+	Error string
+}
+
+// ObservedValue returns the time until the first certificate in the chain expires in seconds.
+func (details Details) ObservedValue() interface{} {
+	return int64(details.ExpiresIn.Seconds())
+}
+
+// ObservedUnit returns the unit of the time until expiry.
+func (details Details) ObservedUnit() string {
+	return "s"
+}
+
+// MeasurementName returns the name of the expiry measurement.
+func (details Details) MeasurementName() string {
+	return "certificateExpiry"
+}
+
+// Output returns the reason that the certificates are not considered healthy.
+func (details Details) Output() string {
+	return details.Error
+}
+
+// loadFunc retrieves the certificate chain to evaluate, starting with the leaf.
+type loadFunc func(ctx context.Context) ([]*x509.Certificate, error)
+
+// checker evaluates a certificate chain.
+type checker struct {
+	// source describes where the certificates come from.
+	source string
+	// load retrieves the certificates.
+	load loadFunc
+	// verifyChain indicates that the chain must be trusted by the root certificate authorities.
+	verifyChain bool
+	// serverName is the hostname that the leaf certificate must be valid for. Empty if the hostname is not verified.
+	serverName string
+	// roots contains the trusted root certificate authorities. Nil if the system roots are used.
+	roots *x509.CertPool
+	// dialer establishes the connection when performing a handshake.
+	dialer *net.Dialer
+	// warnWindow is the time before expiry that the certificates are reported as StateWarn.
+	warnWindow time.Duration
+}
+
+// Option is used to configure optional check behavior.
+type Option func(checker *checker)
+
+// WithWarnWindow configures the time before a certificate in the chain expires that the check is reported as
+// StateWarn. Defaults to 30 days.
+func WithWarnWindow(window time.Duration) Option {
+	return func(checker *checker) {
+		checker.warnWindow = window
+	}
+}
+
+// WithServerName configures the hostname that the leaf certificate must be valid for. When performing a handshake,
+// this also configures the server name sent to the server and defaults to the host of the address. For certificates
+// read from a file or provided directly, the hostname is only verified if this option is provided.
+func WithServerName(name string) Option {
+	return func(checker *checker) {
+		checker.serverName = name
+	}
+}
+
+// WithRootCAs configures the root certificate authorities that the chain presented during a handshake must be trusted
+// by. Defaults to the system roots. Has no effect on certificates read from a file or provided directly.
+func WithRootCAs(roots *x509.CertPool) Option {
+	return func(checker *checker) {
+		checker.roots = roots
+	}
+}
+
+// WithDialer configures the dialer used to establish the connection when performing a handshake. Defaults to a dialer
+// with the default settings.
+func WithDialer(dialer *net.Dialer) Option {
+	return func(checker *checker) {
+		checker.dialer = dialer
+	}
+}
+
+// New creates a health check function that performs a TLS handshake with the provided address, e.g.
+// "example.com:443", and evaluates the certificate chain presented by the server. The check is reported as StateDown
+// if any certificate in the chain has expired or is not yet valid, or if the chain is not trusted or not valid for the
+// server name, and StateWarn if any certificate in the chain expires within the warn window. Otherwise, the check is
+// reported as StateUp. The status details are always of type Details. The handshake is terminated when the context
+// provided to the check function is done, so configure a timeout on the check. The return value will never be nil.
+func New(address string, opts ...Option) health.CheckFunc {
+	checker := newChecker(address, opts)
+	checker.verifyChain = true
+
+	if checker.serverName == "" {
+		host, _, err := net.SplitHostPort(address)
+		if err != nil {
+			host = address
+		}
+		checker.serverName = host
+	}
+
+	checker.load = checker.handshake
+
+	return checker.check
+}
+
+// NewFromFile creates a health check function that reads the PEM encoded certificate chain in the provided file and
+// evaluates it like New, except that the chain is not required to be trusted. The file is read every time the check
+// function is executed so that renewed certificates are picked up. The return value will never be nil.
+func NewFromFile(path string, opts ...Option) health.CheckFunc {
+	checker := newChecker(path, opts)
+	checker.load = func(ctx context.Context) ([]*x509.Certificate, error) {
+		return readFile(path)
+	}
+
+	return checker.check
+}
+
+// NewFromCertificate creates a health check function that evaluates the provided certificate chain like New, except
+// that the chain is not required to be trusted. The return value will never be nil.
+func NewFromCertificate(certificate tls.Certificate, opts ...Option) health.CheckFunc {
+	checker := newChecker("", opts)
+	checker.load = func(ctx context.Context) ([]*x509.Certificate, error) {
+		return parseCertificate(certificate)
+	}
+
+	return checker.check
+}
+
+// newChecker creates a checker for the provided source with the options applied.
+func newChecker(source string, opts []Option) *checker {
+	checker := &checker{
+		source:     source,
+		dialer:     &net.Dialer{},
+		warnWindow: defaultWarnWindow,
+	}
+
+	for _, opt := range opts {
+		opt(checker)
+	}
+
+	return checker
+}
+
+// check retrieves and evaluates the certificate chain.
+func (checker *checker) check(ctx context.Context) health.Status {
+	details := Details{Source: checker.source}
+
+	certificates, err := checker.load(ctx)
+	if err != nil {
+		return down(details, err)
+	}
+	if len(certificates) == 0 {
+		return down(details, errors.New("no certificates found"))
+	}
+
+	now := time.Now()
+	var firstExpiring *x509.Certificate
+	for _, certificate := range certificates {
+		details.Certificates = append(details.Certificates, Certificate{
+			Subject:   certificate.Subject.String(),
+			Issuer:    certificate.Issuer.String(),
+			NotBefore: certificate.NotBefore,
+			NotAfter:  certificate.NotAfter,
+		})
+
+		if firstExpiring == nil || certificate.NotAfter.Before(firstExpiring.NotAfter) {
+			firstExpiring = certificate
+		}
+	}
+	details.ExpiresIn = firstExpiring.NotAfter.Sub(now)
+
+	for _, certificate := range certificates {
+		if now.After(certificate.NotAfter) {
+			return down(details, fmt.Errorf("certificate %q expired at %s", certificate.Subject.String(),
+				certificate.NotAfter.Format(time.RFC3339)))
+		}
+		if now.Before(certificate.NotBefore) {
+			return down(details, fmt.Errorf("certificate %q is not valid until %s", certificate.Subject.String(),
+				certificate.NotBefore.Format(time.RFC3339)))
+		}
+	}
+
+	if err := checker.verify(certificates, now); err != nil {
+		return down(details, err)
+	}
+
+	if details.ExpiresIn < checker.warnWindow {
+		details.Error = fmt.Sprintf("certificate %q expires at %s", firstExpiring.Subject.String(),
+			firstExpiring.NotAfter.Format(time.RFC3339))
+		return health.Status{State: health.StateWarn, Details: details}
+	}
+
+	return health.Status{State: health.StateUp, Details: details}
+}
+
+// verify verifies that the chain is trusted, if required, and that the leaf certificate is valid for the server name,
+// if configured.
+func (checker *checker) verify(certificates []*x509.Certificate, now time.Time) error {
+	leaf := certificates[0]
+
+	if !checker.verifyChain {
+		if checker.serverName == "" {
+			return nil
+		}
+		return leaf.VerifyHostname(checker.serverName)
+	}
+
+	intermediates := x509.NewCertPool()
+	for _, certificate := range certificates[1:] {
+		intermediates.AddCert(certificate)
+	}
+
+	_, err := leaf.Verify(x509.VerifyOptions{
+		DNSName:       checker.serverName,
+		Roots:         checker.roots,
+		Intermediates: intermediates,
+		CurrentTime:   now,
+	})
+
+	return err
+}
+
+// handshake connects to the address and returns the certificate chain presented by the server.
+func (checker *checker) handshake(ctx context.Context) ([]*x509.Certificate, error) {
+	dialer := &tls.Dialer{
+		NetDialer: checker.dialer,
+		Config: &tls.Config{
+			ServerName: checker.serverName,
+			// The chain is verified after the handshake so that the certificates are available even if they are not
+			// valid, e.g. because they have expired.
+			InsecureSkipVerify: true,
+		},
+	}
+
+	conn, err := dialer.DialContext(ctx, "tcp", checker.source)
+	if err != nil {
+		return nil, err
+	}
+	defer conn.Close()
+
+	return conn.(*tls.Conn).ConnectionState().PeerCertificates, nil
+}
+
+// readFile reads the PEM encoded certificates in the file, ignoring any other PEM blocks like private keys.
+func readFile(path string) ([]*x509.Certificate, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var certificates []*x509.Certificate
+	for {
+		var block *pem.Block
+		block, data = pem.Decode(data)
+		if block == nil {
+			break
+		}
+		if block.Type != "CERTIFICATE" {
+			continue
+		}
+
+		certificate, err := x509.ParseCertificate(block.Bytes)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse certificate: %w", err)
+		}
+		certificates = append(certificates, certificate)
+	}
+
+	return certificates, nil
+}
+
+// parseCertificate parses the DER encoded certificates of the TLS certificate.
+func parseCertificate(certificate tls.Certificate) ([]*x509.Certificate, error) {
+	var certificates []*x509.Certificate
+	for _, der := range certificate.Certificate {
+		parsed, err := x509.ParseCertificate(der)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse certificate: %w", err)
+		}
+		certificates = append(certificates, parsed)
+	}
+
+	return certificates, nil
+}
+
+// down creates a StateDown status with the provided error recorded in the details.
+func down(details Details, err error) health.Status {
+	details.Error = err.Error()
+	return health.Status{State: health.StateDown, Details: details}
+}
@@ -0,0 +1,264 @@
+package tlscheck_test
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"net"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/jaredpetersen/go-health/checks/tlscheck"
+	"github.com/jaredpetersen/go-health/health"
+	"github.com/stretchr/testify/assert"
+)
+
+// chain is a certificate authority and a leaf certificate issued by it.
+type chain struct {
+	ca   *x509.Certificate
+	leaf tls.Certificate
+}
+
+// roots returns a pool that trusts the certificate authority of the chain.
+func (chain chain) roots() *x509.CertPool {
+	roots := x509.NewCertPool()
+	roots.AddCert(chain.ca)
+
+	return roots
+}
+
+// newChain creates a certificate authority and a leaf certificate for 127.0.0.1 that expires at the provided time.
+func newChain(t *testing.T, notAfter time.Time) chain {
+	caKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	caTemplate := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "Test CA"},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour * 24 * 365),
+		KeyUsage:              x509.KeyUsageCertSign,
+		BasicConstraintsValid: true,
+		IsCA:                  true,
+	}
+	caDER, err := x509.CreateCertificate(rand.Reader, caTemplate, caTemplate, &caKey.PublicKey, caKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ca, err := x509.ParseCertificate(caDER)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	leafKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	leafTemplate := &x509.Certificate{
+		SerialNumber: big.NewInt(2),
+		Subject:      pkix.Name{CommonName: "localhost"},
+		NotBefore:    time.Now().Add(-time.Hour * 24 * 365),
+		NotAfter:     notAfter,
+		KeyUsage:     x509.KeyUsageDigitalSignature,
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth},
+		IPAddresses:  []net.IP{net.ParseIP("127.0.0.1")},
+		DNSNames:     []string{"localhost"},
+	}
+	leafDER, err := x509.CreateCertificate(rand.Reader, leafTemplate, ca, &leafKey.PublicKey, caKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return chain{
+		ca: ca,
+		leaf: tls.Certificate{
+			Certificate: [][]byte{leafDER, caDER},
+			PrivateKey:  leafKey,
+		},
+	}
+}
+
+// serve starts a TLS server that presents the certificate and returns its address.
+func serve(t *testing.T, certificate tls.Certificate) string {
+	listener, err := tls.Listen("tcp", "127.0.0.1:0", &tls.Config{Certificates: []tls.Certificate{certificate}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { listener.Close() })
+
+	go func() {
+		for {
+			conn, err := listener.Accept()
+			if err != nil {
+				return
+			}
+			go func() {
+				defer conn.Close()
+				conn.(*tls.Conn).Handshake()
+			}()
+		}
+	}()
+
+	return listener.Addr().String()
+}
+
+func TestCheckUp(t *testing.T) {
+	chain := newChain(t, time.Now().Add(time.Hour*24*90))
+	address := serve(t, chain.leaf)
+
+	status := tlscheck.New(address, tlscheck.WithRootCAs(chain.roots()))(context.Background())
+
+	assert.Equal(t, health.StateUp, status.State)
+	details := status.Details.(tlscheck.Details)
+	assert.Equal(t, address, details.Source)
+	assert.Empty(t, details.Error)
+	if assert.Equal(t, 2, len(details.Certificates)) {
+		assert.Equal(t, "CN=localhost", details.Certificates[0].Subject)
+		assert.Equal(t, "CN=Test CA", details.Certificates[0].Issuer)
+		assert.Equal(t, "CN=Test CA", details.Certificates[1].Subject)
+	}
+	assert.InDelta(t, float64(time.Hour*24*90), float64(details.ExpiresIn), float64(time.Minute))
+}
+
+func TestCheckExpiringSoon(t *testing.T) {
+	chain := newChain(t, time.Now().Add(time.Hour*24*10))
+	address := serve(t, chain.leaf)
+
+	status := tlscheck.New(address, tlscheck.WithRootCAs(chain.roots()))(context.Background())
+
+	assert.Equal(t, health.StateWarn, status.State)
+	assert.Contains(t, status.Details.(tlscheck.Details).Error, `certificate "CN=localhost" expires at`)
+}
+
+func TestCheckWarnWindow(t *testing.T) {
+	chain := newChain(t, time.Now().Add(time.Hour*24*10))
+	address := serve(t, chain.leaf)
+
+	status := tlscheck.New(address,
+		tlscheck.WithRootCAs(chain.roots()),
+		tlscheck.WithWarnWindow(time.Hour*24*7))(context.Background())
+
+	assert.Equal(t, health.StateUp, status.State)
+}
+
+func TestCheckExpired(t *testing.T) {
+	chain := newChain(t, time.Now().Add(-time.Hour))
+	address := serve(t, chain.leaf)
+
+	status := tlscheck.New(address, tlscheck.WithRootCAs(chain.roots()))(context.Background())
+
+	assert.Equal(t, health.StateDown, status.State)
+	details := status.Details.(tlscheck.Details)
+	assert.Contains(t, details.Error, `certificate "CN=localhost" expired at`)
+	assert.True(t, details.ExpiresIn < 0, "Expired certificate has time remaining")
+	assert.Equal(t, 2, len(details.Certificates))
+}
+
+func TestCheckHostnameMismatch(t *testing.T) {
+	chain := newChain(t, time.Now().Add(time.Hour*24*90))
+	address := serve(t, chain.leaf)
+
+	status := tlscheck.New(address,
+		tlscheck.WithRootCAs(chain.roots()),
+		tlscheck.WithServerName("example.com"))(context.Background())
+
+	assert.Equal(t, health.StateDown, status.State)
+	assert.Contains(t, status.Details.(tlscheck.Details).Error, "example.com")
+}
+
+func TestCheckUntrusted(t *testing.T) {
+	chain := newChain(t, time.Now().Add(time.Hour*24*90))
+	address := serve(t, chain.leaf)
+
+	status := tlscheck.New(address)(context.Background())
+
+	assert.Equal(t, health.StateDown, status.State)
+	assert.Contains(t, status.Details.(tlscheck.Details).Error, "unknown authority")
+}
+
+func TestCheckConnectionRefused(t *testing.T) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	address := listener.Addr().String()
+	listener.Close()
+
+	status := tlscheck.New(address)(context.Background())
+
+	assert.Equal(t, health.StateDown, status.State)
+	assert.NotEmpty(t, status.Details.(tlscheck.Details).Error)
+}
+
+func TestCheckFromFile(t *testing.T) {
+	chain := newChain(t, time.Now().Add(time.Hour*24*10))
+
+	var data []byte
+	for _, der := range chain.leaf.Certificate {
+		data = append(data, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der})...)
+	}
+	keyDER, err := x509.MarshalECPrivateKey(chain.leaf.PrivateKey.(*ecdsa.PrivateKey))
+	if err != nil {
+		t.Fatal(err)
+	}
+	data = append(data, pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER})...)
+
+	path := filepath.Join(t.TempDir(), "tls.pem")
+	if err := os.WriteFile(path, data, 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	status := tlscheck.NewFromFile(path)(context.Background())
+
+	assert.Equal(t, health.StateWarn, status.State)
+	details := status.Details.(tlscheck.Details)
+	assert.Equal(t, path, details.Source)
+	assert.Equal(t, 2, len(details.Certificates))
+}
+
+func TestCheckFromFileMissing(t *testing.T) {
+	status := tlscheck.NewFromFile(filepath.Join(t.TempDir(), "missing.pem"))(context.Background())
+
+	assert.Equal(t, health.StateDown, status.State)
+	assert.NotEmpty(t, status.Details.(tlscheck.Details).Error)
+}
+
+func TestCheckFromFileWithoutCertificates(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "empty.pem")
+	if err := os.WriteFile(path, []byte("not a certificate"), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	status := tlscheck.NewFromFile(path)(context.Background())
+
+	assert.Equal(t, health.StateDown, status.State)
+	assert.Equal(t, "no certificates found", status.Details.(tlscheck.Details).Error)
+}
+
+func TestCheckFromCertificate(t *testing.T) {
+	chain := newChain(t, time.Now().Add(time.Hour*24*90))
+
+	status := tlscheck.NewFromCertificate(chain.leaf, tlscheck.WithServerName("localhost"))(context.Background())
+	assert.Equal(t, health.StateUp, status.State)
+
+	status = tlscheck.NewFromCertificate(chain.leaf, tlscheck.WithServerName("example.com"))(context.Background())
+	assert.Equal(t, health.StateDown, status.State)
+}
+
+func TestDetails(t *testing.T) {
+	details := tlscheck.Details{ExpiresIn: time.Hour, Error: "certificate expired"}
+
+	assert.Equal(t, int64(3600), details.ObservedValue())
+	assert.Equal(t, "s", details.ObservedUnit())
+	assert.Equal(t, "certificateExpiry", details.MeasurementName())
+	assert.Equal(t, "certificate expired", details.Output())
+}
@@ -0,0 +1,153 @@
+package health
+
+import "sort"
+
+// CheckState is the state of an individual check as provided to an Aggregator.
+type CheckState struct {
+	// Name of the check.
+	Name string
+	// State of the check. Non-critical checks in StateDown are provided as StateWarn.
+	State State
+	// Critical indicates that the check was not configured as non-critical.
+	Critical bool
+	// Weight is the relative importance of the check. Always greater than zero.
+	Weight float64
+}
+
+// Aggregator combines the states of the individual checks into an overall state. The check states are sorted by name.
+// Aggregators must handle an empty slice, which typically should result in StateUp.
+type Aggregator func(checkStates []CheckState) State
+
+// WithAggregator configures the monitor to combine the check states into the overall state using the provided
+// aggregator. The aggregator also combines the check states of each group. WorstOf is used by default.
+func WithAggregator(aggregator Aggregator) Option {
+	return func(mtr *Monitor) {
+		mtr.aggregator = aggregator
+	}
+}
+
+// WorstOf is an aggregator that bubbles up the most degraded check state. For example, if there are three checks and
+// one has a state of StateDown, the overall state will be StateDown.
+func WorstOf(checkStates []CheckState) State {
+	// Use StateUp as the initial state so that it may be overidden by the checks if necessary.
+	// If checks are not configured, then we also default to StateUp.
+	state := StateUp
+	for _, checkState := range checkStates {
+		state = compareState(state, checkState.State)
+	}
+
+	return state
+}
+
+// Quorum creates an aggregator that reports StateDown if fewer than the provided number of checks are passing, meaning
+// they are in StateWarn or StateUp. Otherwise, the overall state is StateUp if every check is in StateUp and StateWarn
+// if not. Without any checks, the overall state is StateUp.
+//
+// The aggregator is also applied to each group, so a group with fewer checks than the quorum is always reported as
+// StateDown. Consider Percentage instead if the monitor uses groups of different sizes.
+func Quorum(quorum int) Aggregator {
+	return func(checkStates []CheckState) State {
+		if len(checkStates) == 0 {
+			return StateUp
+		}
+
+		passing := 0
+		for _, checkState := range checkStates {
+			if checkState.State != StateDown {
+				passing++
+			}
+		}
+
+		return quorumState(checkStates, passing >= quorum)
+	}
+}
+
+// Percentage creates an aggregator that reports StateDown if less than the provided percentage (0 to 100) of the
+// checks are passing, meaning they are in StateWarn or StateUp. Otherwise, the overall state is StateUp if every check
+// is in StateUp and StateWarn if not.
+func Percentage(percentage float64) Aggregator {
+	return func(checkStates []CheckState) State {
+		if len(checkStates) == 0 {
+			return StateUp
+		}
+
+		passing := 0
+		for _, checkState := range checkStates {
+			if checkState.State != StateDown {
+				passing++
+			}
+		}
+
+		return quorumState(checkStates, float64(passing)/float64(len(checkStates))*100 >= percentage)
+	}
+}
+
+// Weighted creates an aggregator that reports StateDown if the combined weight of the passing checks, meaning they are
+// in StateWarn or StateUp, is less than the provided fraction (0 to 1) of the combined weight of all of the checks.
+// Otherwise, the overall state is StateUp if every check is in StateUp and StateWarn if not.
+func Weighted(fraction float64) Aggregator {
+	return func(checkStates []CheckState) State {
+		if len(checkStates) == 0 {
+			return StateUp
+		}
+
+		var passingWeight, totalWeight float64
+		for _, checkState := range checkStates {
+			totalWeight += checkState.Weight
+			if checkState.State != StateDown {
+				passingWeight += checkState.Weight
+			}
+		}
+
+		return quorumState(checkStates, passingWeight/totalWeight >= fraction)
+	}
+}
+
+// quorumState determines the overall state for aggregators that tolerate some checks being in StateDown.
+func quorumState(checkStates []CheckState, met bool) State {
+	if !met {
+		return StateDown
+	}
+
+	for _, checkState := range checkStates {
+		if checkState.State != StateUp {
+			return StateWarn
+		}
+	}
+
+	return StateUp
+}
+
+// aggregateState combines the states of the provided check statuses into an overall state using the monitor's
+// aggregator. The monitor mutex must be held by the caller.
+func (mtr *Monitor) aggregateState(checkStatuses map[string]CheckStatus) State {
+	checkStates := make([]CheckState, 0, len(checkStatuses))
+	for checkName, checkStatus := range checkStatuses {
+		checkState := CheckState{
+			Name:     checkName,
+			State:    checkStatus.Status.State,
+			Critical: true,
+			Weight:   1,
+		}
+
+		if runner, ok := mtr.runners[checkName]; ok {
+			checkState.Critical = !runner.check.NonCritical
+			if runner.check.Weight > 0 {
+				checkState.Weight = runner.check.Weight
+			}
+		}
+
+		// Non-critical checks can at most degrade the overall state to StateWarn
+		if !checkState.Critical && checkState.State == StateDown {
+			checkState.State = StateWarn
+		}
+
+		checkStates = append(checkStates, checkState)
+	}
+
+	sort.Slice(checkStates, func(i, j int) bool {
+		return checkStates[i].Name < checkStates[j].Name
+	})
+
+	return mtr.aggregator(checkStates)
+}
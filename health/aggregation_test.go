@@ -0,0 +1,115 @@
+package health_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/jaredpetersen/go-health/health"
+	"github.com/stretchr/testify/assert"
+)
+
+func newCheckStates(states ...health.State) []health.CheckState {
+	checkStates := make([]health.CheckState, len(states))
+	for i, state := range states {
+		checkStates[i] = health.CheckState{State: state, Critical: true, Weight: 1}
+	}
+
+	return checkStates
+}
+
+func TestWorstOf(t *testing.T) {
+	assert.Equal(t, health.StateUp, health.WorstOf(nil))
+	assert.Equal(t, health.StateUp, health.WorstOf(newCheckStates(health.StateUp, health.StateUp)))
+	assert.Equal(t, health.StateWarn, health.WorstOf(newCheckStates(health.StateUp, health.StateWarn)))
+	assert.Equal(t, health.StateDown, health.WorstOf(newCheckStates(health.StateWarn, health.StateDown)))
+}
+
+func TestQuorum(t *testing.T) {
+	aggregator := health.Quorum(2)
+
+	assert.Equal(t, health.StateUp, aggregator(nil))
+	assert.Equal(t, health.StateUp, aggregator(newCheckStates(health.StateUp, health.StateUp, health.StateUp)))
+	assert.Equal(t, health.StateWarn, aggregator(newCheckStates(health.StateUp, health.StateUp, health.StateDown)))
+	assert.Equal(t, health.StateWarn, aggregator(newCheckStates(health.StateUp, health.StateWarn, health.StateDown)))
+	assert.Equal(t, health.StateDown, aggregator(newCheckStates(health.StateUp, health.StateDown, health.StateDown)))
+}
+
+func TestPercentage(t *testing.T) {
+	aggregator := health.Percentage(50)
+
+	assert.Equal(t, health.StateUp, aggregator(nil))
+	assert.Equal(t, health.StateUp, aggregator(newCheckStates(health.StateUp, health.StateUp)))
+	assert.Equal(t, health.StateWarn, aggregator(newCheckStates(health.StateUp, health.StateDown)))
+	assert.Equal(t, health.StateDown, aggregator(newCheckStates(health.StateUp, health.StateDown, health.StateDown)))
+}
+
+func TestWeighted(t *testing.T) {
+	aggregator := health.Weighted(0.75)
+
+	checkStates := newCheckStates(health.StateUp, health.StateDown, health.StateUp)
+	checkStates[0].Weight = 3
+
+	assert.Equal(t, health.StateUp, aggregator(nil))
+	assert.Equal(t, health.StateWarn, aggregator(checkStates))
+
+	checkStates[0].State = health.StateDown
+	assert.Equal(t, health.StateDown, aggregator(checkStates))
+}
+
+func TestCheckNonCritical(t *testing.T) {
+	healthMonitor := health.New()
+	ctx := context.Background()
+
+	criticalCheckFunc := func(ctx context.Context) health.Status {
+		return health.Status{State: health.StateUp}
+	}
+	criticalCheck := health.NewCheck("db", criticalCheckFunc)
+
+	nonCriticalCheckFunc := func(ctx context.Context) health.Status {
+		return health.Status{State: health.StateDown}
+	}
+	nonCriticalCheck := health.NewCheck("cache", nonCriticalCheckFunc)
+	nonCriticalCheck.NonCritical = true
+
+	healthMonitor.Monitor(ctx, criticalCheck, nonCriticalCheck)
+
+	// Wait for goroutines to kick in
+	time.Sleep(time.Millisecond * 100)
+
+	status := healthMonitor.Check()
+
+	assert.Equal(t, health.StateWarn, status.State)
+	assert.Equal(t, health.StateDown, status.CheckStatuses[nonCriticalCheck.Name].Status.State)
+}
+
+func TestCheckWithAggregator(t *testing.T) {
+	var checkStates []health.CheckState
+	aggregator := func(states []health.CheckState) health.State {
+		checkStates = states
+		return health.StateWarn
+	}
+
+	healthMonitor := health.New(health.WithAggregator(aggregator))
+	ctx := context.Background()
+
+	checkFunc := func(ctx context.Context) health.Status {
+		return health.Status{State: health.StateDown}
+	}
+	checkA := health.NewCheck("checkA", checkFunc)
+	checkA.Weight = 5
+	checkB := health.NewCheck("checkB", checkFunc)
+	checkB.NonCritical = true
+	healthMonitor.Monitor(ctx, checkB, checkA)
+
+	// Wait for goroutines to kick in
+	time.Sleep(time.Millisecond * 100)
+
+	assert.Equal(t, health.StateWarn, healthMonitor.Check().State)
+
+	expectedCheckStates := []health.CheckState{
+		{Name: "checkA", State: health.StateDown, Critical: true, Weight: 5},
+		{Name: "checkB", State: health.StateWarn, Critical: false, Weight: 1},
+	}
+	assert.Equal(t, expectedCheckStates, checkStates)
+}
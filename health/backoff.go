@@ -0,0 +1,90 @@
+package health
+
+import "time"
+
+// defaultBackoffMultiplier is the factor that the backoff interval grows by if a valid multiplier is not configured.
+const defaultBackoffMultiplier = 2
+
+// Backoff defines how the time waited on between executions of a check function grows while the check is in StateDown.
+type Backoff struct {
+	// Initial is the time waited on after the check transitions to StateDown. If left at its zero-value, the check's
+	// TTL is used.
+	Initial time.Duration
+	// Multiplier is the factor that the time waited on grows by after every execution that leaves the check in
+	// StateDown. Values less than or equal to one are treated as two.
+	Multiplier float64
+	// Max is the maximum time waited on between executions. If left at its zero-value, the time waited on grows
+	// without bound.
+	Max time.Duration
+	// ResetOnSuccess returns to the check's TTL as soon as the check transitions out of StateDown. Otherwise, the time
+	// waited on shrinks by the multiplier after every execution until it is back to the TTL.
+	ResetOnSuccess bool
+}
+
+// multiplier returns the configured multiplier or the default if the configured one is not valid.
+func (backoff Backoff) multiplier() float64 {
+	if backoff.Multiplier <= 1 {
+		return defaultBackoffMultiplier
+	}
+
+	return backoff.Multiplier
+}
+
+// grow returns the interval that follows the current one while the check remains in StateDown.
+func (backoff Backoff) grow(current time.Duration, ttl time.Duration) time.Duration {
+	next := backoff.Initial
+	if next <= 0 {
+		next = ttl
+	}
+
+	if current > 0 {
+		next = time.Duration(float64(current) * backoff.multiplier())
+	}
+
+	if backoff.Max > 0 && next > backoff.Max {
+		next = backoff.Max
+	}
+
+	return next
+}
+
+// shrink returns the interval that follows the current one once the check is out of StateDown. Zero indicates that the
+// check is no longer backing off.
+func (backoff Backoff) shrink(current time.Duration, ttl time.Duration) time.Duration {
+	if backoff.ResetOnSuccess || current <= 0 {
+		return 0
+	}
+
+	next := time.Duration(float64(current) / backoff.multiplier())
+	if next <= ttl {
+		return 0
+	}
+
+	return next
+}
+
+// nextInterval determines the time to wait until the next execution of the check function given the current state of
+// the check. The monitor mutex must be held by the caller.
+func (runner *checkRunner) nextInterval(state State) time.Duration {
+	check := runner.check
+
+	if check.Backoff != nil {
+		if state == StateDown {
+			runner.backoffInterval = check.Backoff.grow(runner.backoffInterval, check.TTL)
+		} else {
+			runner.backoffInterval = check.Backoff.shrink(runner.backoffInterval, check.TTL)
+		}
+
+		if runner.backoffInterval > 0 {
+			return runner.backoffInterval
+		}
+
+		return check.TTL
+	}
+
+	if state == StateDown && check.RecoveryInterval > 0 {
+		return check.RecoveryInterval
+	}
+
+	return check.TTL
+}
@@ -0,0 +1,157 @@
+package health_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/jaredpetersen/go-health/health"
+	"github.com/stretchr/testify/assert"
+)
+
+// receiveIntervals receives execution events and returns the interval that each execution scheduled the next one with.
+// Executions are scheduled on a fixed cadence, so the intervals are measured between the scheduled executions rather
+// than from the time that each execution finished.
+func receiveIntervals(t *testing.T, sub *health.Subscription, count int) []time.Duration {
+	var intervals []time.Duration
+	var scheduled time.Time
+	for len(intervals) < count {
+		event := receiveEvent(t, sub)
+		if event.Type != health.EventCheckExecuted {
+			continue
+		}
+
+		checkStatus := event.CheckStatus
+		if scheduled.IsZero() {
+			// The first execution is scheduled immediately
+			scheduled = checkStatus.Timestamp.Add(-checkStatus.Duration)
+		}
+
+		interval := checkStatus.NextExecution.Sub(scheduled)
+		intervals = append(intervals, interval.Round(time.Millisecond*5))
+		scheduled = checkStatus.NextExecution
+	}
+
+	return intervals
+}
+
+func TestCheckBackoff(t *testing.T) {
+	healthMonitor := health.New()
+	ctx := context.Background()
+
+	sub := healthMonitor.Subscribe(health.WithExecutionEvents())
+	defer sub.Unsubscribe()
+
+	results := make(chan health.State, 10)
+	checkFunc := func(ctx context.Context) health.Status {
+		return health.Status{State: <-results}
+	}
+	check := health.NewCheck("check", checkFunc)
+	check.TTL = time.Millisecond * 10
+	check.Backoff = &health.Backoff{
+		Initial:    time.Millisecond * 20,
+		Multiplier: 2,
+		Max:        time.Millisecond * 40,
+	}
+	healthMonitor.Monitor(ctx, check)
+
+	for _, state := range []health.State{
+		health.StateUp,
+		health.StateDown,
+		health.StateDown,
+		health.StateDown,
+		health.StateDown,
+		health.StateUp,
+		health.StateUp,
+		health.StateUp,
+	} {
+		results <- state
+	}
+
+	intervals := receiveIntervals(t, sub, 8)
+
+	expectedIntervals := []time.Duration{
+		time.Millisecond * 10,
+		time.Millisecond * 20,
+		time.Millisecond * 40,
+		time.Millisecond * 40,
+		time.Millisecond * 40,
+		// Shrink back down without resetting
+		time.Millisecond * 20,
+		time.Millisecond * 10,
+		time.Millisecond * 10,
+	}
+	assert.Equal(t, expectedIntervals, intervals)
+}
+
+func TestCheckBackoffResetOnSuccess(t *testing.T) {
+	healthMonitor := health.New()
+	ctx := context.Background()
+
+	sub := healthMonitor.Subscribe(health.WithExecutionEvents())
+	defer sub.Unsubscribe()
+
+	results := make(chan health.State, 10)
+	checkFunc := func(ctx context.Context) health.Status {
+		return health.Status{State: <-results}
+	}
+	check := health.NewCheck("check", checkFunc)
+	check.TTL = time.Millisecond * 10
+	check.Backoff = &health.Backoff{ResetOnSuccess: true}
+	healthMonitor.Monitor(ctx, check)
+
+	for _, state := range []health.State{
+		health.StateDown,
+		health.StateDown,
+		health.StateDown,
+		health.StateWarn,
+	} {
+		results <- state
+	}
+
+	intervals := receiveIntervals(t, sub, 4)
+
+	expectedIntervals := []time.Duration{
+		time.Millisecond * 10,
+		time.Millisecond * 20,
+		time.Millisecond * 40,
+		time.Millisecond * 10,
+	}
+	assert.Equal(t, expectedIntervals, intervals)
+}
+
+func TestCheckRecoveryInterval(t *testing.T) {
+	healthMonitor := health.New()
+	ctx := context.Background()
+
+	sub := healthMonitor.Subscribe(health.WithExecutionEvents())
+	defer sub.Unsubscribe()
+
+	results := make(chan health.State, 10)
+	checkFunc := func(ctx context.Context) health.Status {
+		return health.Status{State: <-results}
+	}
+	check := health.NewCheck("check", checkFunc)
+	check.TTL = time.Millisecond * 50
+	check.RecoveryInterval = time.Millisecond * 10
+	healthMonitor.Monitor(ctx, check)
+
+	for _, state := range []health.State{
+		health.StateUp,
+		health.StateDown,
+		health.StateDown,
+		health.StateUp,
+	} {
+		results <- state
+	}
+
+	intervals := receiveIntervals(t, sub, 4)
+
+	expectedIntervals := []time.Duration{
+		time.Millisecond * 50,
+		time.Millisecond * 10,
+		time.Millisecond * 10,
+		time.Millisecond * 50,
+	}
+	assert.Equal(t, expectedIntervals, intervals)
+}
@@ -30,8 +30,8 @@ func Example() {
 	barHealthCheck.Timeout = time.Second * 2
 	healthMonitor.Monitor(ctx, barHealthCheck)
 
-	// Wait for goroutines to kick off
-	time.Sleep(time.Millisecond * 100)
+	// Wait for all of the checks to execute at least once.
+	healthMonitor.WaitReady(ctx)
 
 	// Retrieve the most recent cached result for all of the checks.
 	healthMonitor.Check()
@@ -44,7 +44,8 @@ func Example_http() {
 	// Prepare the context -- this can be used to stop async monitoring.
 	ctx := context.Background()
 
-	// Set up a generic health checker, though anything that implements the check function will do.
+	// Set up a generic health checker, though anything that implements the check function will do. See the httpcheck
+	// package for a more complete HTTP health checker.
 	httpClient := http.Client{}
 	type HTTPHealthCheckDetails struct {
 		ResponseTime time.Duration
@@ -66,6 +67,7 @@ func Example_http() {
 			if err != nil {
 				return statusDown
 			}
+			defer res.Body.Close()
 
 			if res.StatusCode == http.StatusOK {
 				return health.Status{
@@ -89,8 +91,8 @@ func Example_http() {
 	godevHealthCheck.Timeout = time.Second * 2
 	healthMonitor.Monitor(ctx, godevHealthCheck)
 
-	// Wait for goroutines to kick off
-	time.Sleep(time.Second * 2)
+	// Wait for all of the checks to execute at least once.
+	healthMonitor.WaitReady(ctx)
 
 	// Retrieve the most recent cached result for all of the checks.
 	healthMonitor.Check()
@@ -0,0 +1,128 @@
+package health
+
+import "time"
+
+const (
+	// GroupLiveness is the group for checks that determine if the application is running and does not need to be
+	// restarted, e.g. a Kubernetes liveness probe.
+	GroupLiveness = "liveness"
+	// GroupReadiness is the group for checks that determine if the application is ready to accept traffic, e.g. a
+	// Kubernetes readiness probe.
+	GroupReadiness = "readiness"
+	// GroupStartup is the group for checks that determine if the application has finished starting up, e.g. a
+	// Kubernetes startup probe. This group latches: once it has passed, it always reports StateUp.
+	GroupStartup = "startup"
+)
+
+// WithLatchingGroup configures the group with the provided name to latch once it has passed, just like GroupStartup.
+// A group has passed once all of its checks are in StateWarn or StateUp. From then on, the group is no longer
+// evaluated and always reports StateUp along with the check statuses from the time that it passed.
+func WithLatchingGroup(name string) Option {
+	return func(mtr *Monitor) {
+		mtr.latchingGroups[name] = true
+	}
+}
+
+// Group is a view of the monitor that is limited to the checks in a single group. It may be used anywhere the health of
+// the whole monitor is expected, e.g. to publish a separate HTTP endpoint for each Kubernetes probe.
+type Group struct {
+	// mtr is the monitor containing the checks.
+	mtr *Monitor
+	// name is the name of the group.
+	name string
+}
+
+// Group returns a view of the monitor that is limited to the checks in the group with the provided name.
+func (mtr *Monitor) Group(name string) Group {
+	return Group{mtr: mtr, name: name}
+}
+
+// Check returns the latest cached status for all of the checks in the group.
+func (group Group) Check() MonitorStatus {
+	return group.mtr.CheckGroup(group.name)
+}
+
+// CheckGroup returns the latest cached status for the checks that belong to the group with the provided name. The
+// state combines the states of only those checks in the same way as Check. A group without any checks is reported as
+// StateUp.
+//
+// Latching groups, like GroupStartup, always report StateUp once they have passed. A latching group does not pass
+// until it contains at least one check and every check in it has executed at least once, regardless of the initial
+// state of the checks.
+func (mtr *Monitor) CheckGroup(name string) MonitorStatus {
+	if !mtr.latchingGroups[name] {
+		mtr.mtx.RLock()
+		defer mtr.mtx.RUnlock()
+
+		return mtr.groupStatus(name)
+	}
+
+	mtr.mtx.Lock()
+	defer mtr.mtx.Unlock()
+
+	if latchedStatus, ok := mtr.latchedGroups[name]; ok {
+		return copyMonitorStatus(latchedStatus)
+	}
+
+	return mtr.latchGroup(name)
+}
+
+// latchGroup evaluates the latching group with the provided name and latches it if it has passed, returning the status
+// of the group. The monitor mutex must be held by the caller.
+func (mtr *Monitor) latchGroup(name string) MonitorStatus {
+	groupStatus := mtr.groupStatus(name)
+	if len(groupStatus.CheckStatuses) > 0 && groupStatus.State != StateDown &&
+		!hasPendingCheck(groupStatus.CheckStatuses) {
+		groupStatus.State = StateUp
+		mtr.latchedGroups[name] = copyMonitorStatus(groupStatus)
+	}
+
+	return groupStatus
+}
+
+// latchGroupsOf latches the latching groups that the check belongs to if they have passed so that a pass is captured
+// as soon as it happens rather than only when the group is read. The monitor mutex must be held by the caller.
+func (mtr *Monitor) latchGroupsOf(check Check) {
+	for _, name := range check.Groups {
+		if _, ok := mtr.latchedGroups[name]; !ok && mtr.latchingGroups[name] {
+			mtr.latchGroup(name)
+		}
+	}
+}
+
+// groupStatus determines the status of the checks in the group from the cached check statuses. The monitor mutex must
+// be held by the caller.
+func (mtr *Monitor) groupStatus(name string) MonitorStatus {
+	checkStatuses := make(map[string]CheckStatus)
+
+	now := time.Now()
+	for checkName, runner := range mtr.runners {
+		if runner.check.inGroup(name) {
+			checkStatuses[checkName] = mtr.copyCheckStatus(checkName, now)
+		}
+	}
+
+	return MonitorStatus{State: mtr.aggregateState(checkStatuses), CheckStatuses: checkStatuses}
+}
+
+// inGroup determines if the check belongs to the group with the provided name.
+func (check Check) inGroup(name string) bool {
+	for _, group := range check.Groups {
+		if group == name {
+			return true
+		}
+	}
+
+	return false
+}
+
+// copyMonitorStatus creates a copy of the monitor status so that the check statuses may be returned without being
+// impacted by later modifications.
+func copyMonitorStatus(monitorStatus MonitorStatus) MonitorStatus {
+	checkStatuses := make(map[string]CheckStatus, len(monitorStatus.CheckStatuses))
+	for checkName, checkStatus := range monitorStatus.CheckStatuses {
+		checkStatuses[checkName] = checkStatus
+	}
+
+	return MonitorStatus{State: monitorStatus.State, CheckStatuses: checkStatuses}
+}
@@ -0,0 +1,177 @@
+package health_test
+
+import (
+	"context"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/jaredpetersen/go-health/health"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCheckGroup(t *testing.T) {
+	healthMonitor := health.New()
+	ctx := context.Background()
+
+	processCheckFunc := func(ctx context.Context) health.Status {
+		return health.Status{State: health.StateUp}
+	}
+	processCheck := health.NewCheck("process", processCheckFunc)
+	processCheck.Groups = []string{health.GroupLiveness, health.GroupReadiness}
+
+	dbCheckFunc := func(ctx context.Context) health.Status {
+		return health.Status{State: health.StateDown}
+	}
+	dbCheck := health.NewCheck("db", dbCheckFunc)
+	dbCheck.Groups = []string{health.GroupReadiness}
+
+	cacheCheckFunc := func(ctx context.Context) health.Status {
+		return health.Status{State: health.StateWarn}
+	}
+	cacheCheck := health.NewCheck("cache", cacheCheckFunc)
+	cacheCheck.Groups = []string{"optional"}
+
+	healthMonitor.Monitor(ctx, processCheck, dbCheck, cacheCheck)
+
+	// Wait for goroutines to kick in
+	time.Sleep(time.Millisecond * 100)
+
+	livenessStatus := healthMonitor.CheckGroup(health.GroupLiveness)
+	assert.Equal(t, health.StateUp, livenessStatus.State)
+	assert.Equal(t, 1, len(livenessStatus.CheckStatuses))
+	assert.Contains(t, livenessStatus.CheckStatuses, processCheck.Name)
+
+	readinessStatus := healthMonitor.CheckGroup(health.GroupReadiness)
+	assert.Equal(t, health.StateDown, readinessStatus.State)
+	assert.Equal(t, 2, len(readinessStatus.CheckStatuses))
+	assert.Contains(t, readinessStatus.CheckStatuses, processCheck.Name)
+	assert.Contains(t, readinessStatus.CheckStatuses, dbCheck.Name)
+
+	optionalStatus := healthMonitor.Group("optional").Check()
+	assert.Equal(t, health.StateWarn, optionalStatus.State)
+	assert.Equal(t, 1, len(optionalStatus.CheckStatuses))
+
+	emptyStatus := healthMonitor.CheckGroup("empty")
+	assert.Equal(t, health.StateUp, emptyStatus.State)
+	assert.Equal(t, 0, len(emptyStatus.CheckStatuses))
+
+	// Groups do not impact the overall state
+	assert.Equal(t, health.StateDown, healthMonitor.Check().State)
+	assert.Equal(t, 3, len(healthMonitor.Check().CheckStatuses))
+}
+
+func TestCheckGroupStartupLatches(t *testing.T) {
+	healthMonitor := health.New()
+	ctx := context.Background()
+
+	atomicState := int32(health.StateDown)
+	checkFunc := func(ctx context.Context) health.Status {
+		return health.Status{State: health.State(atomic.LoadInt32(&atomicState))}
+	}
+	check := health.NewCheck("migration", checkFunc)
+	check.TTL = time.Millisecond * 10
+	check.Groups = []string{health.GroupStartup}
+	healthMonitor.Monitor(ctx, check)
+
+	// Wait for goroutines to kick in
+	time.Sleep(time.Millisecond * 50)
+
+	assert.Equal(t, health.StateDown, healthMonitor.CheckGroup(health.GroupStartup).State)
+
+	atomic.StoreInt32(&atomicState, int32(health.StateWarn))
+
+	// Wait for the result to be processed
+	time.Sleep(time.Millisecond * 50)
+
+	startupStatus := healthMonitor.CheckGroup(health.GroupStartup)
+	assert.Equal(t, health.StateUp, startupStatus.State)
+	assert.Equal(t, health.StateWarn, startupStatus.CheckStatuses[check.Name].Status.State)
+
+	atomic.StoreInt32(&atomicState, int32(health.StateDown))
+
+	// Wait for the result to be processed
+	time.Sleep(time.Millisecond * 50)
+
+	// The group is no longer evaluated once it has passed
+	startupStatus = healthMonitor.CheckGroup(health.GroupStartup)
+	assert.Equal(t, health.StateUp, startupStatus.State)
+	assert.Equal(t, health.StateWarn, startupStatus.CheckStatuses[check.Name].Status.State)
+
+	assert.Equal(t, health.StateDown, healthMonitor.Check().State)
+}
+
+func TestCheckGroupWithLatchingGroup(t *testing.T) {
+	healthMonitor := health.New(health.WithLatchingGroup("warmup"))
+	ctx := context.Background()
+
+	atomicState := int32(health.StateUp)
+	checkFunc := func(ctx context.Context) health.Status {
+		return health.Status{State: health.State(atomic.LoadInt32(&atomicState))}
+	}
+	check := health.NewCheck("cache", checkFunc)
+	check.TTL = time.Millisecond * 10
+	check.Groups = []string{"warmup"}
+	healthMonitor.Monitor(ctx, check)
+
+	// Wait for the result to be processed
+	time.Sleep(time.Millisecond * 50)
+
+	assert.Equal(t, health.StateUp, healthMonitor.CheckGroup("warmup").State)
+
+	atomic.StoreInt32(&atomicState, int32(health.StateDown))
+
+	// Wait for the result to be processed
+	time.Sleep(time.Millisecond * 50)
+
+	assert.Equal(t, health.StateUp, healthMonitor.CheckGroup("warmup").State)
+}
+
+func TestCheckGroupLatchingRequiresChecks(t *testing.T) {
+	healthMonitor := health.New()
+	ctx := context.Background()
+
+	// Read the group before any of its checks are registered
+	assert.Equal(t, health.StateUp, healthMonitor.CheckGroup(health.GroupStartup).State)
+
+	checkFunc := func(ctx context.Context) health.Status {
+		return health.Status{State: health.StateDown}
+	}
+	check := health.NewCheck("migration", checkFunc)
+	check.Groups = []string{health.GroupStartup}
+	healthMonitor.Monitor(ctx, check)
+
+	// Wait for the result to be processed
+	time.Sleep(time.Millisecond * 50)
+
+	startupStatus := healthMonitor.CheckGroup(health.GroupStartup)
+	assert.Equal(t, health.StateDown, startupStatus.State)
+	assert.Equal(t, 1, len(startupStatus.CheckStatuses))
+}
+
+func TestCheckGroupLatchesWithoutBeingRead(t *testing.T) {
+	healthMonitor := health.New()
+	ctx := context.Background()
+
+	atomicState := int32(health.StateUp)
+	checkFunc := func(ctx context.Context) health.Status {
+		return health.Status{State: health.State(atomic.LoadInt32(&atomicState))}
+	}
+	check := health.NewCheck("migration", checkFunc)
+	check.TTL = time.Millisecond * 10
+	check.Groups = []string{health.GroupStartup}
+	healthMonitor.Monitor(ctx, check)
+
+	// Wait for the result to be processed
+	time.Sleep(time.Millisecond * 50)
+
+	// The check fails again before anybody reads the group
+	atomic.StoreInt32(&atomicState, int32(health.StateDown))
+
+	// Wait for the result to be processed
+	time.Sleep(time.Millisecond * 50)
+
+	startupStatus := healthMonitor.CheckGroup(health.GroupStartup)
+	assert.Equal(t, health.StateUp, startupStatus.State)
+	assert.Equal(t, health.StateUp, startupStatus.CheckStatuses[check.Name].Status.State)
+}
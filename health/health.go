@@ -3,6 +3,12 @@ package health
 
 import (
 	"context"
+	"errors"
+	"fmt"
+	"math/rand"
+	"runtime/debug"
+	"sort"
+	"strings"
 	"sync"
 	"time"
 )
@@ -21,11 +27,26 @@ const (
 	StateUp
 )
 
+// String returns the lowercase name of the state, e.g. "up".
+func (state State) String() string {
+	switch state {
+	case StateDown:
+		return "down"
+	case StateWarn:
+		return "warn"
+	case StateUp:
+		return "up"
+	default:
+		return fmt.Sprintf("State(%d)", int(state))
+	}
+}
+
 // MonitorStatus represents the health of the all of the resources being checked.
 type MonitorStatus struct {
-	// State is a high level indicator for the health of all of the checks. It combines all of the check states
-	// together and bubbles up the most degraded. For example, if there are three checks and one has a state of
-	// StateDown, the overall state will be StateDown.
+	// State is a high level indicator for the health of all of the checks. By default, it combines all of the check
+	// states together and bubbles up the most degraded. For example, if there are three checks and one has a state of
+	// StateDown, the overall state will be StateDown. Non-critical checks can at most degrade the state to StateWarn.
+	// The way the states are combined may be changed with WithAggregator.
 	State State
 	// CheckStatuses contains all of the resource statuses. The map key is the name of the check.
 	CheckStatuses map[string]CheckStatus
@@ -33,10 +54,42 @@ type MonitorStatus struct {
 
 // CheckStatus indicates the health of an individual check and when that information was determined.
 type CheckStatus struct {
-	// Status of the resource.
+	// Status of the resource. If the check is configured with failure or success thresholds, this is the status that
+	// the check has settled on, which may differ from the result of the most recent execution.
 	Status Status
 	// Timestamp is the time the status was determined.
 	Timestamp time.Time
+	// LastResult is the status returned by the most recent execution of the check function, regardless of any
+	// configured thresholds.
+	LastResult Status
+	// ConsecutiveFailures is the number of consecutive executions that have resulted in StateDown.
+	ConsecutiveFailures int
+	// ConsecutiveSuccesses is the number of consecutive executions that have resulted in StateWarn or StateUp.
+	ConsecutiveSuccesses int
+	// Duration is the time that the most recent execution of the check function took.
+	Duration time.Duration
+	// Executions is the total number of times that the check has been evaluated.
+	Executions uint64
+	// Failures is the total number of executions that have resulted in StateDown.
+	Failures uint64
+	// Timeouts is the total number of executions that have exceeded the check's timeout.
+	Timeouts uint64
+	// LastSuccess is the time of the most recent execution that resulted in StateWarn or StateUp. Left at its
+	// zero-value if the check has never succeeded.
+	LastSuccess time.Time
+	// Uptime contains the time spent in each state for each of the monitor's uptime windows. Only set if the check is
+	// configured to track uptime.
+	Uptime []Uptime
+	// NextExecution is the time that the check function is next scheduled to execute. Left at its zero-value before
+	// the first execution.
+	NextExecution time.Time
+	// Pending indicates that the check function has not executed yet, in which case the status is the initial state of
+	// the check and the timestamp is left at its zero-value.
+	Pending bool
+	// Stale indicates that the status is older than the max age of the check, or that the check has not executed
+	// within the max age of being registered, in which case the state has been degraded to the stale state of the
+	// check.
+	Stale bool
 }
 
 // Status indicates resource health state and may contain any additional, arbitrary details that are relevant.
@@ -59,11 +112,79 @@ type Check struct {
 	// It is your responsibility to ensure that this function respects the provided context so that the logic may be
 	// terminated early. The provided context will be given a deadline if the check is configured with a timeout.
 	Func CheckFunc
-	// TTL is the time that should be waited on between executions of the health check function.
+	// TTL is the time between the start of each execution of the health check function. Executions are scheduled on a
+	// fixed cadence, so the time taken by the function does not delay later executions. If an execution takes longer
+	// than the TTL, the executions that were missed in the meantime are skipped.
 	TTL time.Duration
+	// MaxInitialDelay is the max time that the first execution of the health check function is delayed by. The actual
+	// delay is random so that checks and replicas started at the same time do not execute in lockstep. If left at its
+	// zero-value, the first execution starts immediately.
+	MaxInitialDelay time.Duration
+	// Jitter is the fraction (0 to 1) of the time between executions that each execution is randomly moved earlier or
+	// later by, e.g. 0.1 for up to 10%. Jitter does not accumulate, so the cadence does not drift. If left at its
+	// zero-value, executions start exactly on schedule.
+	Jitter float64
+	// Backoff stretches the time waited on between executions while the check is in StateDown so that a resource that
+	// is already struggling is not hammered. If nil, the TTL is used regardless of state.
+	Backoff *Backoff
+	// RecoveryInterval is the time that should be waited on between executions while the check is in StateDown, in
+	// place of the TTL. This is typically shorter than the TTL so that recovery is detected quickly. Ignored if a
+	// backoff is configured. If left at its zero-value, the TTL is used.
+	RecoveryInterval time.Duration
 	// Timeout is the max time that the check function may execute in before the provided context communicates
 	// termination.
 	Timeout time.Duration
+	// EnforceTimeout stops waiting on the check function once the timeout has been exceeded instead of relying on the
+	// function to respect the context. The check is immediately reported as StateDown with TimeoutDetails and the
+	// execution is abandoned. New executions are not started while an abandoned execution is still running. Has no
+	// effect if a timeout is not configured.
+	EnforceTimeout bool
+	// AbandonLimit is the max time that an abandoned execution may block new executions of the check function. Once
+	// exceeded, the abandoned execution is left to run in the background and a new execution is started. If left at
+	// its zero-value, new executions are blocked until the abandoned execution returns.
+	AbandonLimit time.Duration
+	// FailureThreshold is the number of consecutive executions that must result in StateDown before the check
+	// transitions to StateDown. Values less than one are treated as one, meaning the check transitions immediately.
+	FailureThreshold int
+	// SuccessThreshold is the number of consecutive executions that must result in StateWarn or StateUp before a
+	// check in StateDown transitions out of it. This applies to the initial StateDown as well. Values less than one are
+	// treated as one, meaning the check transitions immediately.
+	SuccessThreshold int
+	// NonCritical indicates that the check is optional and can at most degrade the overall state to StateWarn, even
+	// when the check itself is in StateDown.
+	NonCritical bool
+	// Weight is the relative importance of the check for aggregators that take it into account, like Weighted. Values
+	// less than or equal to zero are treated as one.
+	Weight float64
+	// HistorySize is the number of most recent executions to retain for the check, which are available via History. If
+	// left at its zero-value, no history is retained.
+	HistorySize int
+	// TrackUptime enables tracking of the time that the check spends in each state over the monitor's uptime windows,
+	// which is available via Uptime and on the CheckStatus. Memory usage grows with the number of state changes in the
+	// longest window.
+	TrackUptime bool
+	// SLOTarget is the fraction of time (0 to 1, exclusive) that the check is expected to be in StateWarn or StateUp,
+	// e.g. 0.999. It is used to calculate the error budget burn rate when uptime is tracked. If left at its
+	// zero-value, the burn rate is not calculated.
+	SLOTarget float64
+	// MaxAge is the max time since the most recent execution of the health check function before the cached status is
+	// considered stale, which indicates that polling is stuck. Stale statuses are reported with their state degraded
+	// to the StaleState. The max age is never shorter than the time until the next scheduled execution plus the
+	// timeout, so that a backoff does not cause the status to be reported as stale. The age of a check that has not
+	// executed yet is measured from the time that it was registered, with a max age of at least the max initial delay
+	// plus the timeout. A typical value is three times the TTL plus the timeout. Subscribers are notified once a status
+	// goes stale. If left at its zero-value, staleness is not detected.
+	MaxAge time.Duration
+	// StaleState is the state that a stale status is degraded to. The state is only ever degraded, so StateUp has no
+	// effect. Defaults to StateDown.
+	StaleState State
+	// InitialState is the state that the check is reported as until the check function has executed for the first
+	// time. Defaults to StateDown so that the application is not reported as healthy before it has been checked.
+	InitialState State
+	// Groups contains the names of the groups that the check belongs to, e.g. GroupReadiness. Groups may be evaluated
+	// independently of the other checks via CheckGroup. Checks always contribute to the overall state reported by
+	// Check, regardless of their groups.
+	Groups []string
 }
 
 // NewCheck creates a new health check with suitable default values.
@@ -80,104 +201,689 @@ func NewCheck(name string, checkFunc CheckFunc) Check {
 	}
 }
 
+// PanicDetails is used as the status details when a check function panics. The panic is recovered and the check is
+// reported as StateDown so that a single faulty check function cannot crash the application.
+type PanicDetails struct {
+	// Check is the name of the check whose function panicked.
+	Check string
+	// Value is the value that was passed to panic.
+	Value interface{}
+	// Stack is the stack trace of the goroutine at the time of the panic.
+	Stack string
+	// ConsecutivePanics is the number of consecutive executions of the check function that have panicked, including
+	// this one.
+	ConsecutivePanics int
+}
+
+// PanicHandler is a function that is called whenever a check function panics.
+type PanicHandler func(details PanicDetails)
+
+var (
+	// ErrDuplicateCheck indicates that a check with the same name is already registered with the monitor.
+	ErrDuplicateCheck = errors.New("health: duplicate check")
+	// ErrCheckNotFound indicates that no check with the provided name is registered with the monitor.
+	ErrCheckNotFound = errors.New("health: check not found")
+	// ErrMonitorStopped indicates that the monitor has been stopped and may no longer start checks.
+	ErrMonitorStopped = errors.New("health: monitor stopped")
+)
+
+// StopError is returned by Stop when one or more checks did not exit before the provided context was done.
+type StopError struct {
+	// Checks contains the names of the checks that were still executing, sorted alphabetically.
+	Checks []string
+	// Err is the error from the context that ended the wait.
+	Err error
+}
+
+// Error returns a description of the checks that did not stop.
+func (err *StopError) Error() string {
+	return fmt.Sprintf("health: checks did not stop: %s: %v", strings.Join(err.Checks, ", "), err.Err)
+}
+
+// Unwrap returns the context error so that StopError may be compared against context.DeadlineExceeded and
+// context.Canceled.
+func (err *StopError) Unwrap() error {
+	return err.Err
+}
+
+// checkRunner tracks the goroutine that polls an individual check.
+type checkRunner struct {
+	// check is the check being polled.
+	check Check
+	// ctx is the context the check was registered with. It is retained so that the check may be replaced.
+	ctx context.Context
+	// registered is the time that the check was registered.
+	registered time.Time
+	// staleTimer notifies the subscribers once the cached status goes stale. Nil if the check does not detect staleness.
+	// Must be accessed while holding the monitor mutex.
+	staleTimer *time.Timer
+	// cancel terminates the polling goroutine.
+	cancel context.CancelFunc
+	// pool executes the check function when the monitor limits concurrency, in which case the workers of the pool act
+	// as the polling goroutine, never executing the same check at the same time. Nil if the check is polled by its own
+	// goroutine.
+	pool *workerPool
+	// pollCtx is the context that the check function is executed with when the monitor limits concurrency. It is done
+	// once cancel is called.
+	pollCtx context.Context
+	// done is closed once the polling goroutine and any check function execution it started have returned.
+	done chan struct{}
+	// consecutivePanics is the number of consecutive executions of the check function that have panicked. It is only
+	// accessed by the polling goroutine.
+	consecutivePanics int
+	// executions tracks the goroutines used to execute the check function when the timeout is enforced.
+	executions sync.WaitGroup
+	// runningExecutions is the number of check function executions that have not yet returned when the timeout is
+	// enforced. Must be accessed atomically.
+	runningExecutions int32
+	// abandoned is closed once the most recently abandoned execution returns. Nil if there is no abandoned execution
+	// blocking new executions. It is only accessed by the polling goroutine.
+	abandoned <-chan struct{}
+	// abandonedAt is the time that the most recently abandoned execution was abandoned. It is only accessed by the
+	// polling goroutine.
+	abandonedAt time.Time
+	// history contains the most recent executions of the check. Must be accessed while holding the monitor mutex.
+	history *executionHistory
+	// uptime tracks the time spent in each state. Nil if the check does not track uptime. Must be accessed while
+	// holding the monitor mutex.
+	uptime *uptimeTracker
+	// backoffInterval is the current interval between executions as stretched by the check's backoff. Zero if the
+	// check is not backing off. Must be accessed while holding the monitor mutex.
+	backoffInterval time.Duration
+	// scheduled is the time that the most recent execution was scheduled for, without jitter. It is only accessed by
+	// the polling goroutine.
+	scheduled time.Time
+	// next is the time of the upcoming scheduled execution, including jitter. It is only accessed by the polling
+	// goroutine.
+	next time.Time
+	// rand is the source of randomness for the initial delay and jitter. It is only accessed by the polling goroutine.
+	rand *rand.Rand
+	// refresh is signaled to execute the check function immediately.
+	refresh chan struct{}
+	// pendingRefresh is the refresh that is waiting on the next execution triggered by the refresh channel. Nil if no
+	// refresh has been requested. Must be accessed while holding the monitor mutex.
+	pendingRefresh *refreshCall
+}
+
 // Monitor coordinates checks and executes their status functions to determine application health.
 type Monitor struct {
 	// checkStatuses is a cache of all of the check function results, the key being the name of the check.
 	checkStatuses map[string]CheckStatus
-	// mtx is a read-write mutex used to coordinate reads and writes to the checkStatuses cache.
+	// runners contains the polling goroutine for each registered check, the key being the name of the check.
+	runners map[string]*checkRunner
+	// activeRunners contains every polling goroutine that has not yet exited, including those belonging to checks that
+	// have since been unregistered or replaced.
+	activeRunners map[*checkRunner]struct{}
+	// stopped indicates that Stop has been called and that no new checks may be started.
+	stopped bool
+	// panicHandler is called whenever a check function panics. May be nil.
+	panicHandler PanicHandler
+	// subscriptions contains all of the subscribers that are notified of events.
+	subscriptions map[*Subscription]struct{}
+	// publishedState is the monitor state that was most recently published to the subscribers.
+	publishedState State
+	// aggregator combines the check states into the overall state.
+	aggregator Aggregator
+	// uptimeWindows contains the rolling windows that uptime is calculated over.
+	uptimeWindows []time.Duration
+	// latchingGroups contains the names of the groups that latch once they have passed.
+	latchingGroups map[string]bool
+	// latchedGroups contains the status of each latching group at the time that it passed, the key being the name of
+	// the group.
+	latchedGroups map[string]MonitorStatus
+	// pool limits the number of check functions that execute at the same time. Nil if concurrency is not limited.
+	pool *workerPool
+	// minRefreshInterval is the min time since the last execution of a check before Refresh executes it again.
+	minRefreshInterval time.Duration
+	// ready is closed once every registered check has executed at least once. It is replaced whenever a check that
+	// has not executed yet is registered after it has been closed.
+	ready chan struct{}
+	// mtx is a read-write mutex used to coordinate reads and writes to the checkStatuses cache, the runners, and the
+	// subscriptions.
 	mtx sync.RWMutex
 }
 
+// Option is used to configure optional monitor behavior.
+type Option func(mtr *Monitor)
+
+// WithPanicHandler configures the monitor to call the provided handler whenever a check function panics. The handler
+// is called from the goroutine polling the check and must not block.
+func WithPanicHandler(handler PanicHandler) Option {
+	return func(mtr *Monitor) {
+		mtr.panicHandler = handler
+	}
+}
+
 // New creates a health monitor that monitors the provided checks. The return value will never be nil.
-func New() *Monitor {
+func New(opts ...Option) *Monitor {
 	// Cache the check status results in a map organized by check name as the key.
 	checkStatuses := make(map[string](CheckStatus))
 
-	return &Monitor{checkStatuses: checkStatuses}
+	mtr := &Monitor{
+		checkStatuses: checkStatuses,
+		runners:       make(map[string]*checkRunner),
+		activeRunners: make(map[*checkRunner]struct{}),
+		subscriptions: make(map[*Subscription]struct{}),
+		// A monitor without any checks is considered healthy
+		publishedState:     StateUp,
+		aggregator:         WorstOf,
+		uptimeWindows:      defaultUptimeWindows,
+		minRefreshInterval: defaultMinRefreshInterval,
+		latchingGroups:     map[string]bool{GroupStartup: true},
+		latchedGroups:      make(map[string]MonitorStatus),
+		ready:              make(chan struct{}),
+	}
+
+	for _, opt := range opts {
+		opt(mtr)
+	}
+
+	// A monitor without any checks is considered ready
+	mtr.updateReadiness()
+
+	return mtr
 }
 
-// setCheckStatus updates the check status cache in a thread-safe manner using the monitor mutex.
-func (mtr *Monitor) setCheckStatus(checkName string, checkStatus CheckStatus) {
+// setCheckStatus updates the check status cache with the result of a check function execution in a thread-safe manner
+// using the monitor mutex and returns the time to wait until the next execution. The update is discarded if the runner
+// no longer belongs to a registered check, which happens when the check has been unregistered or replaced while its
+// function was executing.
+func (mtr *Monitor) setCheckStatus(runner *checkRunner, result executionResult) time.Duration {
+	wait := runner.check.TTL
+
 	mtr.mtx.Lock()
-	mtr.checkStatuses[checkName] = checkStatus
+	if mtr.runners[runner.check.Name] == runner {
+		previous := mtr.checkStatuses[runner.check.Name]
+		next := nextCheckStatus(runner.check, previous, result)
+		now := time.Now()
+		if result.refreshed && runner.next.After(now) {
+			// Refreshes do not skip the upcoming scheduled execution
+			next.NextExecution = runner.next
+		} else {
+			next.NextExecution = runner.nextRun(runner.nextInterval(next.Status.State), now)
+		}
+		wait = next.NextExecution.Sub(now)
+		mtr.checkStatuses[runner.check.Name] = next
+		runner.history.add(newExecution(result))
+		if runner.uptime != nil {
+			runner.uptime.record(next.Timestamp, next.Status.State)
+		}
+		mtr.watchStaleness(runner)
+
+		mtr.latchGroupsOf(runner.check)
+		mtr.publishCheckStatus(runner.check.Name, runner.degradeStale(previous, now), next, true)
+		mtr.publishMonitorState()
+
+		if result.refreshed {
+			runner.completeRefresh()
+		}
+
+		if previous.Pending {
+			mtr.updateReadiness()
+		}
+	}
 	mtr.mtx.Unlock()
+
+	if wait < 0 {
+		wait = 0
+	}
+
+	return wait
 }
 
 // Monitor starts a goroutine for each check that executes the check's function and caches the result. This goroutine
 // will wait between polls as defined by check's TTL to avoid spamming the resource being evaluated. If a timeout is
 // set on the check, the context provided to Monitor will be wrapped in a deadline context and provided to the check
-// function to facilitate early termination.
+// function to facilitate early termination. When the monitor limits concurrency with WithMaxConcurrency, the checks
+// are executed by the shared workers of the pool instead of a goroutine each.
+//
+// Polling stops when the provided context is done or when Stop is called. Checks provided after Stop has been called
+// and checks with a name that is already registered are ignored. Use Register if you need to know whether a check
+// was rejected.
 func (mtr *Monitor) Monitor(ctx context.Context, checks ...Check) {
 	for _, check := range checks {
-		// Initialize the cache as StateDown
-		initialStatus := CheckStatus{
+		_ = mtr.Register(ctx, check)
+	}
+}
+
+// Register starts a goroutine that executes the check's function and caches the result, just like Monitor. An error
+// wrapping ErrDuplicateCheck is returned if a check with the same name is already registered and ErrMonitorStopped is
+// returned if Stop has been called.
+func (mtr *Monitor) Register(ctx context.Context, check Check) error {
+	mtr.mtx.Lock()
+	defer mtr.mtx.Unlock()
+
+	if mtr.stopped {
+		return ErrMonitorStopped
+	}
+
+	if _, ok := mtr.runners[check.Name]; ok {
+		return fmt.Errorf("%w: %s", ErrDuplicateCheck, check.Name)
+	}
+
+	mtr.startRunner(ctx, check)
+
+	return nil
+}
+
+// Unregister stops polling the check with the provided name and removes it from the cache. The check function may
+// still be executing when Unregister returns but its result will be discarded. An error wrapping ErrCheckNotFound is
+// returned if no check with the provided name is registered.
+func (mtr *Monitor) Unregister(name string) error {
+	mtr.mtx.Lock()
+	defer mtr.mtx.Unlock()
+
+	runner, ok := mtr.runners[name]
+	if !ok {
+		return fmt.Errorf("%w: %s", ErrCheckNotFound, name)
+	}
+
+	runner.cancel()
+	runner.stopStaleTimer()
+	delete(mtr.runners, name)
+	delete(mtr.checkStatuses, name)
+	mtr.updateReadiness()
+
+	mtr.publishMonitorState()
+
+	return nil
+}
+
+// Replace swaps out the definition of a registered check with the provided one, matched by name. Polling of the
+// previous definition is stopped and polling of the new definition is started using the context that the check was
+// originally registered with. The cached status of the previous definition, including its statistics, is retained
+// until the new definition has executed for the first time so that the check does not flap while it is redefined. An
+// error wrapping ErrCheckNotFound is returned if no check with the same name is registered and ErrMonitorStopped is
+// returned if Stop has been called.
+func (mtr *Monitor) Replace(check Check) error {
+	mtr.mtx.Lock()
+	defer mtr.mtx.Unlock()
+
+	if mtr.stopped {
+		return ErrMonitorStopped
+	}
+
+	runner, ok := mtr.runners[check.Name]
+	if !ok {
+		return fmt.Errorf("%w: %s", ErrCheckNotFound, check.Name)
+	}
+
+	runner.cancel()
+	runner.stopStaleTimer()
+	mtr.startRunner(runner.ctx, check)
+
+	return nil
+}
+
+// startRunner initializes the cache for the check and starts the goroutine that polls it. The cached status of a
+// replaced check is retained unless the replaced check had not executed yet. The monitor mutex must be held by the
+// caller.
+func (mtr *Monitor) startRunner(ctx context.Context, check Check) {
+	// Initialize the cache with the initial state until the check function has executed
+	previous, replaced := mtr.checkStatuses[check.Name]
+	if !replaced || previous.Pending {
+		initial := CheckStatus{
 			Status: Status{
-				State: StateDown,
+				State: check.InitialState,
 			},
+			Pending: true,
 		}
-		mtr.setCheckStatus(check.Name, initialStatus)
+		mtr.checkStatuses[check.Name] = initial
+		mtr.updateReadiness()
 
-		// Start polling the check resource asynchronously
-		go func(check Check) {
-			for {
+		if replaced {
+			mtr.publishCheckStatus(check.Name, previous, initial, false)
+		}
+		mtr.publishMonitorState()
+	}
+
+	// Start polling the check resource asynchronously
+	checkCtx, cancel := context.WithCancel(ctx)
+	runner := &checkRunner{
+		check:      check,
+		ctx:        ctx,
+		registered: time.Now(),
+		cancel:     cancel,
+		done:       make(chan struct{}),
+		refresh:    make(chan struct{}, 1),
+		history:    newExecutionHistory(check.HistorySize),
+	}
+	if check.TrackUptime {
+		runner.uptime = newUptimeTracker(maxDuration(mtr.uptimeWindows))
+	}
+	mtr.runners[check.Name] = runner
+	mtr.activeRunners[runner] = struct{}{}
+	mtr.watchStaleness(runner)
+
+	if mtr.pool != nil {
+		runner.pool = mtr.pool
+		runner.pollCtx = checkCtx
+		runner.cancel = func() {
+			cancel()
+			if mtr.pool.remove(runner) {
+				go mtr.retire(runner)
+			}
+		}
+		mtr.pool.add(runner, runner.initialDelay())
+		return
+	}
+
+	go mtr.poll(checkCtx, runner)
+}
+
+// poll executes the runner's check function on a fixed cadence defined by the check's TTL, or its backoff and recovery
+// interval while in StateDown, until the context is done.
+func (mtr *Monitor) poll(ctx context.Context, runner *checkRunner) {
+	defer mtr.retire(runner)
+	defer runner.cancel()
+
+	ttlTimer := time.NewTimer(runner.initialDelay())
+	defer ttlTimer.Stop()
+
+	for {
+		var refreshed bool
+		select {
+		case <-ctx.Done():
+			return
+		case <-ttlTimer.C:
+		case <-runner.refresh:
+			refreshed = true
+			if !ttlTimer.Stop() {
 				select {
-				case <-ctx.Done():
-					return
+				case <-ttlTimer.C:
 				default:
-					var checkStatus CheckStatus
-					if check.Timeout > 0 {
-						checkStatus = executeCheckWithTimeout(ctx, check)
-					} else {
-						checkStatus = executeCheck(ctx, check)
-					}
-
-					mtr.setCheckStatus(check.Name, checkStatus)
-					time.Sleep(check.TTL)
 				}
 			}
-		}(check)
+		}
+
+		result, ok := mtr.execute(ctx, runner, func() {})
+		if !ok {
+			return
+		}
+
+		// Discard executions interrupted by Stop or the context so that the cached status is preserved
+		if ctx.Err() != nil {
+			return
+		}
+		result.refreshed = refreshed
+
+		ttlTimer.Reset(mtr.setCheckStatus(runner, result))
+	}
+}
+
+// executeScheduled executes the runner's check function once on a worker of the pool and schedules the next execution,
+// just like a single iteration of poll.
+func (mtr *Monitor) executeScheduled(runner *checkRunner, refreshed bool) {
+	ctx := runner.pollCtx
+
+	var next time.Time
+	result, ok := mtr.execute(ctx, runner, mtr.pool.release)
+	if ok && ctx.Err() == nil {
+		result.refreshed = refreshed
+		wait := mtr.setCheckStatus(runner, result)
+		next = time.Now().Add(wait)
+	} else {
+		// Discard executions interrupted by Stop or the context so that the cached status is preserved
+		runner.cancel()
+	}
+
+	if !mtr.pool.reschedule(runner, next) {
+		// Retire asynchronously since abandoned executions may keep the runner from retiring indefinitely
+		go mtr.retire(runner)
+	}
+}
+
+// retire waits for any check function executions started by the runner to return, then marks the runner as having
+// exited.
+func (mtr *Monitor) retire(runner *checkRunner) {
+	runner.executions.Wait()
+
+	mtr.mtx.Lock()
+	delete(mtr.activeRunners, runner)
+	runner.completeRefresh()
+	mtr.mtx.Unlock()
+
+	close(runner.done)
+}
+
+// execute executes the runner's check function once and calls the provided release function once the check function
+// has returned. False is returned if the context is done before an execution enforcing its timeout returns.
+func (mtr *Monitor) execute(ctx context.Context, runner *checkRunner, release func()) (executionResult, bool) {
+	check := runner.check
+
+	var result executionResult
+	ok := true
+	if check.Timeout > 0 && check.EnforceTimeout {
+		// Abandoned executions hold on to their worker until the check function returns so that they still count
+		// towards the max concurrency
+		result, ok = runner.executeCheckEnforcingTimeout(ctx, release)
+	} else if check.Timeout > 0 {
+		result = executeCheckWithTimeout(ctx, check)
+		release()
+	} else {
+		result = executeCheck(ctx, check)
+		release()
+	}
+
+	if !ok {
+		return executionResult{}, false
+	}
+
+	if result.panicked {
+		mtr.handlePanic(runner, &result.checkStatus)
+	} else {
+		runner.consecutivePanics = 0
+	}
+
+	return result, true
+}
+
+// handlePanic records a recovered check function panic against the runner and notifies the panic handler.
+func (mtr *Monitor) handlePanic(runner *checkRunner, checkStatus *CheckStatus) {
+	runner.consecutivePanics++
+
+	details := checkStatus.Status.Details.(PanicDetails)
+	details.ConsecutivePanics = runner.consecutivePanics
+	checkStatus.Status.Details = details
+
+	if mtr.panicHandler != nil {
+		mtr.panicHandler(details)
+	}
+}
+
+// copyActiveRunners returns all of the polling goroutines that have not yet exited in a thread-safe manner.
+func (mtr *Monitor) copyActiveRunners() []*checkRunner {
+	mtr.mtx.RLock()
+	defer mtr.mtx.RUnlock()
+
+	runners := make([]*checkRunner, 0, len(mtr.activeRunners))
+	for runner := range mtr.activeRunners {
+		runners = append(runners, runner)
+	}
+
+	return runners
+}
+
+// Stop terminates all of the polling goroutines and waits for any check functions that are currently executing to
+// return. Stop waits until all of the checks have exited or the provided context is done, whichever comes first. If
+// the context is done first, a *StopError is returned that contains the names of the checks that are still executing.
+//
+// The cached check statuses remain available via Check after the monitor has been stopped. A stopped monitor cannot
+// be restarted.
+func (mtr *Monitor) Stop(ctx context.Context) error {
+	mtr.mtx.Lock()
+	mtr.stopped = true
+	mtr.mtx.Unlock()
+
+	runners := mtr.copyActiveRunners()
+	for _, runner := range runners {
+		runner.cancel()
+	}
+
+	for _, runner := range runners {
+		select {
+		case <-runner.done:
+		case <-ctx.Done():
+			return newStopError(ctx.Err(), runners)
+		}
+	}
+
+	return nil
+}
+
+// newStopError creates a StopError that lists the runners that have not yet exited.
+func newStopError(err error, runners []*checkRunner) *StopError {
+	var checkNames []string
+	for _, runner := range runners {
+		select {
+		case <-runner.done:
+		default:
+			checkNames = append(checkNames, runner.check.Name)
+		}
+	}
+	sort.Strings(checkNames)
+
+	return &StopError{Checks: checkNames, Err: err}
+}
+
+// Wait blocks until all of the polling goroutines that have been started by the monitor exit, either because their
+// context is done or because Stop was called. Unlike Stop, Wait does not terminate the goroutines itself.
+func (mtr *Monitor) Wait() {
+	for _, runner := range mtr.copyActiveRunners() {
+		<-runner.done
 	}
 }
 
 // Check returns the latest cached status for all of the configured checks.
 func (mtr *Monitor) Check() MonitorStatus {
-	// Use StateUp as the initial state so that it may be overidden by the checks if necessary.
-	// If checks are not configured, then we also default to StateUp.
-	state := StateUp
-
 	// Create a copy of the internal check status map so that we can return it without it being impacted by updates
 	// being performed by the monitor goroutines.
 	checkStatuses := make(map[string]CheckStatus)
 
 	mtr.mtx.RLock()
 
-	for checkName, checkStatus := range mtr.checkStatuses {
-		state = compareState(state, checkStatus.Status.State)
-		checkStatuses[checkName] = checkStatus
+	now := time.Now()
+	for checkName := range mtr.checkStatuses {
+		checkStatuses[checkName] = mtr.copyCheckStatus(checkName, now)
 	}
 
-	monitorStatus := MonitorStatus{State: state, CheckStatuses: checkStatuses}
+	monitorStatus := MonitorStatus{State: mtr.aggregateState(checkStatuses), CheckStatuses: checkStatuses}
 
 	mtr.mtx.RUnlock()
 
 	return monitorStatus
 }
 
-// executeCheck executes the check function using the provided context and updates the check information.
-func executeCheck(ctx context.Context, check Check) CheckStatus {
-	return CheckStatus{
-		Status:    check.Func(ctx),
-		Timestamp: time.Now(),
+// copyCheckStatus returns the cached status of the check along with the uptime as of the provided time, if tracked,
+// degraded if it is stale. The monitor mutex must be held by the caller.
+func (mtr *Monitor) copyCheckStatus(checkName string, now time.Time) CheckStatus {
+	checkStatus := mtr.checkStatuses[checkName]
+
+	runner, ok := mtr.runners[checkName]
+	if !ok {
+		return checkStatus
+	}
+
+	if runner.uptime != nil {
+		checkStatus.Uptime = runner.uptime.calculate(now, mtr.uptimeWindows, runner.check.SLOTarget)
 	}
+
+	return runner.degradeStale(checkStatus, now)
 }
 
-// executeCheck executes the check function using the provided context, wrapped with a deadline set to the check's
-// configured timeout, and updates the check information.
-func executeCheckWithTimeout(ctx context.Context, check Check) CheckStatus {
+// executionResult is the outcome of a single evaluation of a check.
+type executionResult struct {
+	// checkStatus is the status determined by the execution. Only the Status, Timestamp, and Duration are set.
+	checkStatus CheckStatus
+	// panicked indicates that the check function panicked.
+	panicked bool
+	// timedOut indicates that the check's timeout was exceeded.
+	timedOut bool
+	// refreshed indicates that the execution was requested via Refresh.
+	refreshed bool
+}
+
+// executeCheck executes the check function using the provided context and updates the check information. If the
+// check function panics, the panic is recovered and the check information is set to StateDown with PanicDetails.
+func executeCheck(ctx context.Context, check Check) (result executionResult) {
+	start := time.Now()
+
+	defer func() {
+		if recovered := recover(); recovered != nil {
+			result = executionResult{
+				checkStatus: CheckStatus{
+					Status: Status{
+						State: StateDown,
+						Details: PanicDetails{
+							Check: check.Name,
+							Value: recovered,
+							Stack: string(debug.Stack()),
+						},
+					},
+					Timestamp: time.Now(),
+					Duration:  time.Since(start),
+				},
+				panicked: true,
+			}
+		}
+	}()
+
+	return executionResult{
+		checkStatus: CheckStatus{
+			Status:    check.Func(ctx),
+			Timestamp: time.Now(),
+			Duration:  time.Since(start),
+		},
+	}
+}
+
+// executeCheckWithTimeout executes the check function using the provided context, wrapped with a deadline set to the
+// check's configured timeout, and updates the check information.
+func executeCheckWithTimeout(ctx context.Context, check Check) executionResult {
 	timeoutCtx, cancelTimeout := context.WithTimeout(ctx, check.Timeout)
 	defer cancelTimeout()
 
-	return executeCheck(timeoutCtx, check)
+	result := executeCheck(timeoutCtx, check)
+	result.timedOut = timeoutCtx.Err() == context.DeadlineExceeded && ctx.Err() == nil
+
+	return result
+}
+
+// nextCheckStatus determines the check status that follows the previous one given the result of the latest check
+// function execution, holding on to the previous status until the check's failure or success threshold is met.
+func nextCheckStatus(check Check, previous CheckStatus, result executionResult) CheckStatus {
+	next := CheckStatus{
+		Status:      result.checkStatus.Status,
+		Timestamp:   result.checkStatus.Timestamp,
+		LastResult:  result.checkStatus.Status,
+		Duration:    result.checkStatus.Duration,
+		Executions:  previous.Executions + 1,
+		Failures:    previous.Failures,
+		Timeouts:    previous.Timeouts,
+		LastSuccess: previous.LastSuccess,
+	}
+
+	if result.timedOut {
+		next.Timeouts++
+	}
+
+	failed := result.checkStatus.Status.State == StateDown
+	if failed {
+		next.ConsecutiveFailures = previous.ConsecutiveFailures + 1
+		next.Failures++
+	} else {
+		next.ConsecutiveSuccesses = previous.ConsecutiveSuccesses + 1
+		next.LastSuccess = result.checkStatus.Timestamp
+	}
+
+	wasDown := previous.Status.State == StateDown
+	if failed && !wasDown && next.ConsecutiveFailures < check.FailureThreshold {
+		next.Status = previous.Status
+	} else if !failed && wasDown && next.ConsecutiveSuccesses < check.SuccessThreshold {
+		next.Status = previous.Status
+	}
+
+	return next
 }
 
 // compareState compares states and returns the most degraded state of the two.
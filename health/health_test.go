@@ -55,6 +55,7 @@ func TestCheckInitiallyDown(t *testing.T) {
 		Status: health.Status{
 			State: health.StateDown,
 		},
+		Pending: true,
 	}
 
 	assert.Equal(t, health.StateDown, status.State)
@@ -385,3 +386,665 @@ func TestCheckCancelContextStopsCheck(t *testing.T) {
 	assert.Equal(t, checkACounterBefore, checkACounterAfter, "Check A is still executing")
 	assert.Equal(t, checkBCounterBefore, checkBCounterAfter, "Check B is still executing")
 }
+
+func TestStop(t *testing.T) {
+	healthMonitor := health.New()
+	ctx := context.Background()
+
+	var atomicCheckCounter int32
+	checkFunc := func(ctx context.Context) health.Status {
+		atomic.AddInt32(&atomicCheckCounter, 1)
+		return health.Status{State: health.StateUp}
+	}
+	checkA := health.NewCheck("checkA", checkFunc)
+	checkA.TTL = time.Millisecond * 10
+	checkB := health.NewCheck("checkB", checkFunc)
+	checkB.TTL = time.Hour
+	healthMonitor.Monitor(ctx, checkA, checkB)
+
+	// Wait for goroutines to kick in
+	time.Sleep(time.Millisecond * 100)
+
+	stopCtx, cancelStop := context.WithTimeout(context.Background(), time.Second)
+	defer cancelStop()
+
+	err := healthMonitor.Stop(stopCtx)
+	assert.NoError(t, err)
+
+	checkCounterBefore := atomic.LoadInt32(&atomicCheckCounter)
+
+	// Wait to see if goroutines are continuing
+	time.Sleep(time.Millisecond * 100)
+
+	assert.Equal(t, checkCounterBefore, atomic.LoadInt32(&atomicCheckCounter), "Checks are still executing")
+
+	// Cached statuses are still available
+	status := healthMonitor.Check()
+	assert.Equal(t, health.StateUp, status.State)
+	assert.Equal(t, 2, len(status.CheckStatuses))
+}
+
+func TestStopPreservesCachedStatus(t *testing.T) {
+	healthMonitor := health.New()
+	ctx := context.Background()
+
+	var atomicCheckCounter int32
+	checkFunc := func(ctx context.Context) health.Status {
+		if atomic.AddInt32(&atomicCheckCounter, 1) == 1 {
+			return health.Status{State: health.StateUp}
+		}
+
+		// Block until the execution is interrupted
+		<-ctx.Done()
+		return health.Status{State: health.StateDown}
+	}
+	check := health.NewCheck("check", checkFunc)
+	check.TTL = time.Millisecond * 10
+	check.Timeout = time.Hour
+	healthMonitor.Monitor(ctx, check)
+
+	// Wait for goroutines to kick in
+	time.Sleep(time.Millisecond * 50)
+
+	stopCtx, cancelStop := context.WithTimeout(context.Background(), time.Second)
+	defer cancelStop()
+
+	err := healthMonitor.Stop(stopCtx)
+	assert.NoError(t, err)
+
+	checkStatus := healthMonitor.Check().CheckStatuses[check.Name]
+	assert.Equal(t, health.StateUp, checkStatus.Status.State)
+	assert.Equal(t, uint64(1), checkStatus.Executions)
+	assert.Equal(t, uint64(0), checkStatus.Timeouts)
+}
+
+func TestStopWaitsForExecution(t *testing.T) {
+	healthMonitor := health.New()
+	ctx := context.Background()
+
+	var atomicFinished int32
+	checkFunc := func(ctx context.Context) health.Status {
+		<-ctx.Done()
+		time.Sleep(time.Millisecond * 50)
+		atomic.StoreInt32(&atomicFinished, 1)
+		return health.Status{State: health.StateUp}
+	}
+	check := health.NewCheck("check", checkFunc)
+	healthMonitor.Monitor(ctx, check)
+
+	// Wait for goroutines to kick in
+	time.Sleep(time.Millisecond * 50)
+
+	stopCtx, cancelStop := context.WithTimeout(context.Background(), time.Second)
+	defer cancelStop()
+
+	err := healthMonitor.Stop(stopCtx)
+	assert.NoError(t, err)
+	assert.Equal(t, int32(1), atomic.LoadInt32(&atomicFinished), "Stop returned before the check function returned")
+}
+
+func TestStopDeadlineExceeded(t *testing.T) {
+	healthMonitor := health.New()
+	ctx := context.Background()
+
+	release := make(chan struct{})
+	defer close(release)
+
+	stuckCheckFunc := func(ctx context.Context) health.Status {
+		// Ignore the context entirely
+		<-release
+		return health.Status{State: health.StateUp}
+	}
+	stuckCheck := health.NewCheck("stuck", stuckCheckFunc)
+
+	okCheckFunc := func(ctx context.Context) health.Status {
+		return health.Status{State: health.StateUp}
+	}
+	okCheck := health.NewCheck("ok", okCheckFunc)
+
+	healthMonitor.Monitor(ctx, stuckCheck, okCheck)
+
+	// Wait for goroutines to kick in
+	time.Sleep(time.Millisecond * 50)
+
+	stopCtx, cancelStop := context.WithTimeout(context.Background(), time.Millisecond*100)
+	defer cancelStop()
+
+	err := healthMonitor.Stop(stopCtx)
+
+	var stopErr *health.StopError
+	if assert.ErrorAs(t, err, &stopErr) {
+		assert.Equal(t, []string{"stuck"}, stopErr.Checks)
+	}
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+}
+
+func TestStopIgnoresNewChecks(t *testing.T) {
+	healthMonitor := health.New()
+	ctx := context.Background()
+
+	err := healthMonitor.Stop(ctx)
+	assert.NoError(t, err)
+
+	var atomicCheckCounter int32
+	checkFunc := func(ctx context.Context) health.Status {
+		atomic.AddInt32(&atomicCheckCounter, 1)
+		return health.Status{State: health.StateUp}
+	}
+	check := health.NewCheck("check", checkFunc)
+	healthMonitor.Monitor(ctx, check)
+
+	// Wait for goroutines to kick in
+	time.Sleep(time.Millisecond * 50)
+
+	assert.Equal(t, int32(0), atomic.LoadInt32(&atomicCheckCounter), "Check executed after stop")
+	assert.Equal(t, 0, len(healthMonitor.Check().CheckStatuses))
+}
+
+func TestWait(t *testing.T) {
+	healthMonitor := health.New()
+	ctx, cancel := context.WithCancel(context.Background())
+
+	checkFunc := func(ctx context.Context) health.Status {
+		return health.Status{State: health.StateUp}
+	}
+	check := health.NewCheck("check", checkFunc)
+	healthMonitor.Monitor(ctx, check)
+
+	waited := make(chan struct{})
+	go func() {
+		healthMonitor.Wait()
+		close(waited)
+	}()
+
+	select {
+	case <-waited:
+		assert.Fail(t, "Wait returned before the context was cancelled")
+	case <-time.After(time.Millisecond * 100):
+	}
+
+	cancel()
+
+	select {
+	case <-waited:
+	case <-time.After(time.Second):
+		assert.Fail(t, "Wait did not return after the context was cancelled")
+	}
+}
+
+func TestRegister(t *testing.T) {
+	healthMonitor := health.New()
+	ctx := context.Background()
+
+	checkFunc := func(ctx context.Context) health.Status {
+		return health.Status{State: health.StateWarn}
+	}
+	check := health.NewCheck("check", checkFunc)
+
+	err := healthMonitor.Register(ctx, check)
+	assert.NoError(t, err)
+
+	// Wait for goroutines to kick in
+	time.Sleep(time.Millisecond * 100)
+
+	status := healthMonitor.Check()
+
+	assert.Equal(t, health.StateWarn, status.State)
+	assert.Equal(t, 1, len(status.CheckStatuses))
+	assert.Equal(t, health.Status{State: health.StateWarn}, status.CheckStatuses[check.Name].Status)
+}
+
+func TestRegisterDuplicate(t *testing.T) {
+	healthMonitor := health.New()
+	ctx := context.Background()
+
+	var atomicCheckACounter int32
+	checkAFunc := func(ctx context.Context) health.Status {
+		atomic.AddInt32(&atomicCheckACounter, 1)
+		return health.Status{State: health.StateUp}
+	}
+	checkA := health.NewCheck("check", checkAFunc)
+
+	var atomicCheckBCounter int32
+	checkBFunc := func(ctx context.Context) health.Status {
+		atomic.AddInt32(&atomicCheckBCounter, 1)
+		return health.Status{State: health.StateDown}
+	}
+	checkB := health.NewCheck("check", checkBFunc)
+
+	err := healthMonitor.Register(ctx, checkA)
+	assert.NoError(t, err)
+
+	err = healthMonitor.Register(ctx, checkB)
+	assert.ErrorIs(t, err, health.ErrDuplicateCheck)
+
+	// Monitor also ignores the duplicate
+	healthMonitor.Monitor(ctx, checkB)
+
+	// Wait for goroutines to kick in
+	time.Sleep(time.Millisecond * 100)
+
+	assert.GreaterOrEqual(t, atomic.LoadInt32(&atomicCheckACounter), int32(1), "Check A did not execute")
+	assert.Equal(t, int32(0), atomic.LoadInt32(&atomicCheckBCounter), "Duplicate check B executed")
+	assert.Equal(t, health.StateUp, healthMonitor.Check().State)
+}
+
+func TestRegisterStopped(t *testing.T) {
+	healthMonitor := health.New()
+	ctx := context.Background()
+
+	err := healthMonitor.Stop(ctx)
+	assert.NoError(t, err)
+
+	checkFunc := func(ctx context.Context) health.Status {
+		return health.Status{State: health.StateUp}
+	}
+	err = healthMonitor.Register(ctx, health.NewCheck("check", checkFunc))
+	assert.ErrorIs(t, err, health.ErrMonitorStopped)
+}
+
+func TestUnregister(t *testing.T) {
+	healthMonitor := health.New()
+	ctx := context.Background()
+
+	var atomicCheckCounter int32
+	checkAFunc := func(ctx context.Context) health.Status {
+		atomic.AddInt32(&atomicCheckCounter, 1)
+		return health.Status{State: health.StateDown}
+	}
+	checkA := health.NewCheck("checkA", checkAFunc)
+	checkA.TTL = time.Millisecond * 10
+
+	checkBFunc := func(ctx context.Context) health.Status {
+		return health.Status{State: health.StateUp}
+	}
+	checkB := health.NewCheck("checkB", checkBFunc)
+
+	healthMonitor.Monitor(ctx, checkA, checkB)
+
+	// Wait for goroutines to kick in
+	time.Sleep(time.Millisecond * 100)
+
+	assert.Equal(t, health.StateDown, healthMonitor.Check().State)
+
+	err := healthMonitor.Unregister(checkA.Name)
+	assert.NoError(t, err)
+
+	status := healthMonitor.Check()
+	assert.Equal(t, health.StateUp, status.State)
+	assert.Equal(t, 1, len(status.CheckStatuses))
+
+	// Wait for cancel to kick in
+	time.Sleep(time.Millisecond * 50)
+
+	checkCounterBefore := atomic.LoadInt32(&atomicCheckCounter)
+
+	// Wait to see if goroutines are continuing
+	time.Sleep(time.Millisecond * 100)
+
+	assert.Equal(t, checkCounterBefore, atomic.LoadInt32(&atomicCheckCounter), "Unregistered check is still executing")
+
+	// The name may be reused after it is unregistered
+	err = healthMonitor.Register(ctx, checkA)
+	assert.NoError(t, err)
+}
+
+func TestUnregisterNotFound(t *testing.T) {
+	healthMonitor := health.New()
+
+	err := healthMonitor.Unregister("check")
+	assert.ErrorIs(t, err, health.ErrCheckNotFound)
+}
+
+func TestUnregisterDiscardsInFlightResult(t *testing.T) {
+	healthMonitor := health.New()
+	ctx := context.Background()
+
+	release := make(chan struct{})
+	returned := make(chan struct{})
+	checkAFunc := func(ctx context.Context) health.Status {
+		defer close(returned)
+		<-release
+		return health.Status{State: health.StateDown}
+	}
+	checkA := health.NewCheck("check", checkAFunc)
+	healthMonitor.Monitor(ctx, checkA)
+
+	// Wait for goroutines to kick in
+	time.Sleep(time.Millisecond * 50)
+
+	err := healthMonitor.Unregister(checkA.Name)
+	assert.NoError(t, err)
+
+	close(release)
+	<-returned
+
+	// Wait for the result to be processed
+	time.Sleep(time.Millisecond * 50)
+
+	assert.Equal(t, 0, len(healthMonitor.Check().CheckStatuses))
+}
+
+func TestReplace(t *testing.T) {
+	healthMonitor := health.New()
+	ctx := context.Background()
+
+	var atomicCheckACounter int32
+	checkAFunc := func(ctx context.Context) health.Status {
+		atomic.AddInt32(&atomicCheckACounter, 1)
+		return health.Status{State: health.StateDown}
+	}
+	checkA := health.NewCheck("check", checkAFunc)
+	checkA.TTL = time.Millisecond * 10
+	healthMonitor.Monitor(ctx, checkA)
+
+	// Wait for goroutines to kick in
+	time.Sleep(time.Millisecond * 50)
+
+	assert.Equal(t, health.StateDown, healthMonitor.Check().State)
+
+	checkBFunc := func(ctx context.Context) health.Status {
+		_, ok := ctx.Deadline()
+		assert.True(t, ok, "Replacement check was not supplied with a deadline")
+
+		return health.Status{State: health.StateUp}
+	}
+	checkB := health.NewCheck("check", checkBFunc)
+	checkB.Timeout = time.Second
+
+	err := healthMonitor.Replace(checkB)
+	assert.NoError(t, err)
+
+	// Wait for goroutines to kick in
+	time.Sleep(time.Millisecond * 50)
+
+	checkACounterBefore := atomic.LoadInt32(&atomicCheckACounter)
+
+	status := healthMonitor.Check()
+	assert.Equal(t, health.StateUp, status.State)
+	assert.Equal(t, 1, len(status.CheckStatuses))
+
+	// Wait to see if goroutines are continuing
+	time.Sleep(time.Millisecond * 100)
+
+	assert.Equal(t, checkACounterBefore, atomic.LoadInt32(&atomicCheckACounter), "Replaced check is still executing")
+}
+
+func TestReplaceRetainsCachedStatus(t *testing.T) {
+	healthMonitor := health.New()
+	ctx := context.Background()
+
+	checkAFunc := func(ctx context.Context) health.Status {
+		return health.Status{State: health.StateUp}
+	}
+	checkA := health.NewCheck("check", checkAFunc)
+	healthMonitor.Monitor(ctx, checkA)
+
+	// Wait for goroutines to kick in
+	time.Sleep(time.Millisecond * 50)
+
+	release := make(chan struct{})
+	checkBFunc := func(ctx context.Context) health.Status {
+		<-release
+		return health.Status{State: health.StateWarn}
+	}
+	checkB := health.NewCheck("check", checkBFunc)
+	checkB.TTL = time.Second * 10
+
+	err := healthMonitor.Replace(checkB)
+	assert.NoError(t, err)
+
+	// The previous status is reported until the new definition has executed
+	status := healthMonitor.Check()
+	assert.Equal(t, health.StateUp, status.State)
+	checkStatus := status.CheckStatuses[checkB.Name]
+	assert.False(t, checkStatus.Pending, "Replaced check is pending")
+	assert.Equal(t, uint64(1), checkStatus.Executions)
+
+	close(release)
+
+	// Wait for the result to be processed
+	time.Sleep(time.Millisecond * 50)
+
+	status = healthMonitor.Check()
+	assert.Equal(t, health.StateWarn, status.State)
+	assert.Equal(t, uint64(2), status.CheckStatuses[checkB.Name].Executions)
+}
+
+func TestReplaceNotFound(t *testing.T) {
+	healthMonitor := health.New()
+
+	checkFunc := func(ctx context.Context) health.Status {
+		return health.Status{State: health.StateUp}
+	}
+	err := healthMonitor.Replace(health.NewCheck("check", checkFunc))
+	assert.ErrorIs(t, err, health.ErrCheckNotFound)
+}
+
+func TestCheckPanic(t *testing.T) {
+	var atomicHandlerCounter int32
+	panicDetailsChan := make(chan health.PanicDetails, 10)
+	panicHandler := func(details health.PanicDetails) {
+		atomic.AddInt32(&atomicHandlerCounter, 1)
+		select {
+		case panicDetailsChan <- details:
+		default:
+		}
+	}
+
+	healthMonitor := health.New(health.WithPanicHandler(panicHandler))
+	ctx := context.Background()
+
+	checkFunc := func(ctx context.Context) health.Status {
+		panic("something went wrong")
+	}
+	check := health.NewCheck("check", checkFunc)
+	check.TTL = time.Millisecond * 40
+	healthMonitor.Monitor(ctx, check)
+
+	// Wait for goroutines to kick in and panic a few times
+	time.Sleep(time.Millisecond * 100)
+
+	status := healthMonitor.Check()
+
+	assert.Equal(t, health.StateDown, status.State)
+	assert.Equal(t, 1, len(status.CheckStatuses))
+
+	checkStatus := status.CheckStatuses[check.Name]
+	assert.Equal(t, health.StateDown, checkStatus.Status.State)
+	assert.False(t, checkStatus.Timestamp.IsZero(), "Check status timestamp was not updated")
+
+	details, ok := checkStatus.Status.Details.(health.PanicDetails)
+	if assert.True(t, ok, "Check status details are not panic details") {
+		assert.Equal(t, check.Name, details.Check)
+		assert.Equal(t, "something went wrong", details.Value)
+		assert.Contains(t, details.Stack, "health_test.go")
+		assert.GreaterOrEqual(t, details.ConsecutivePanics, 2)
+	}
+
+	assert.GreaterOrEqual(t, atomic.LoadInt32(&atomicHandlerCounter), int32(2), "Panic handler was not called")
+	firstDetails := <-panicDetailsChan
+	assert.Equal(t, 1, firstDetails.ConsecutivePanics)
+	secondDetails := <-panicDetailsChan
+	assert.Equal(t, 2, secondDetails.ConsecutivePanics)
+}
+
+func TestCheckPanicRecovers(t *testing.T) {
+	healthMonitor := health.New()
+	ctx := context.Background()
+
+	var atomicCheckCounter int32
+	checkFunc := func(ctx context.Context) health.Status {
+		if atomic.AddInt32(&atomicCheckCounter, 1)%2 == 1 {
+			panic("something went wrong")
+		}
+		return health.Status{State: health.StateUp}
+	}
+	check := health.NewCheck("check", checkFunc)
+	check.TTL = time.Millisecond * 100
+	check.Timeout = time.Second
+	healthMonitor.Monitor(ctx, check)
+
+	// Wait for goroutines to kick in
+	time.Sleep(time.Millisecond * 50)
+
+	details, ok := healthMonitor.Check().CheckStatuses[check.Name].Status.Details.(health.PanicDetails)
+	assert.True(t, ok, "Check status details are not panic details")
+	assert.Equal(t, 1, details.ConsecutivePanics)
+
+	// Wait for the next execution, which does not panic
+	time.Sleep(time.Millisecond * 100)
+
+	assert.Equal(t, health.Status{State: health.StateUp}, healthMonitor.Check().CheckStatuses[check.Name].Status)
+
+	// Wait for the next execution, which panics again
+	time.Sleep(time.Millisecond * 100)
+
+	details, ok = healthMonitor.Check().CheckStatuses[check.Name].Status.Details.(health.PanicDetails)
+	assert.True(t, ok, "Check status details are not panic details")
+	assert.Equal(t, 1, details.ConsecutivePanics, "Consecutive panic count was not reset")
+}
+
+func TestCheckFailureThreshold(t *testing.T) {
+	healthMonitor := health.New()
+	ctx := context.Background()
+
+	results := make(chan health.State, 10)
+	checkFunc := func(ctx context.Context) health.Status {
+		return health.Status{State: <-results}
+	}
+	check := health.NewCheck("check", checkFunc)
+	check.TTL = time.Millisecond
+	check.FailureThreshold = 3
+	healthMonitor.Monitor(ctx, check)
+
+	assertCheckStatus := func(state health.State, lastState health.State, failures int, successes int) {
+		// Wait for the result to be processed
+		time.Sleep(time.Millisecond * 50)
+
+		checkStatus := healthMonitor.Check().CheckStatuses[check.Name]
+		assert.Equal(t, state, checkStatus.Status.State)
+		assert.Equal(t, lastState, checkStatus.LastResult.State)
+		assert.Equal(t, failures, checkStatus.ConsecutiveFailures)
+		assert.Equal(t, successes, checkStatus.ConsecutiveSuccesses)
+	}
+
+	results <- health.StateUp
+	assertCheckStatus(health.StateUp, health.StateUp, 0, 1)
+
+	results <- health.StateDown
+	assertCheckStatus(health.StateUp, health.StateDown, 1, 0)
+
+	results <- health.StateDown
+	assertCheckStatus(health.StateUp, health.StateDown, 2, 0)
+
+	results <- health.StateWarn
+	assertCheckStatus(health.StateWarn, health.StateWarn, 0, 1)
+
+	results <- health.StateDown
+	results <- health.StateDown
+	results <- health.StateDown
+	assertCheckStatus(health.StateDown, health.StateDown, 3, 0)
+
+	results <- health.StateUp
+	assertCheckStatus(health.StateUp, health.StateUp, 0, 1)
+}
+
+func TestCheckSuccessThreshold(t *testing.T) {
+	healthMonitor := health.New()
+	ctx := context.Background()
+
+	results := make(chan health.State, 10)
+	checkFunc := func(ctx context.Context) health.Status {
+		return health.Status{State: <-results}
+	}
+	check := health.NewCheck("check", checkFunc)
+	check.TTL = time.Millisecond
+	check.SuccessThreshold = 2
+	healthMonitor.Monitor(ctx, check)
+
+	assertCheckStatus := func(state health.State, lastState health.State, failures int, successes int) {
+		// Wait for the result to be processed
+		time.Sleep(time.Millisecond * 50)
+
+		checkStatus := healthMonitor.Check().CheckStatuses[check.Name]
+		assert.Equal(t, state, checkStatus.Status.State)
+		assert.Equal(t, lastState, checkStatus.LastResult.State)
+		assert.Equal(t, failures, checkStatus.ConsecutiveFailures)
+		assert.Equal(t, successes, checkStatus.ConsecutiveSuccesses)
+		assert.False(t, checkStatus.Timestamp.IsZero(), "Check status timestamp was not updated")
+	}
+
+	// The initial StateDown is held until the threshold is met
+	results <- health.StateUp
+	assertCheckStatus(health.StateDown, health.StateUp, 0, 1)
+
+	results <- health.StateWarn
+	assertCheckStatus(health.StateWarn, health.StateWarn, 0, 2)
+
+	results <- health.StateDown
+	assertCheckStatus(health.StateDown, health.StateDown, 1, 0)
+
+	results <- health.StateUp
+	assertCheckStatus(health.StateDown, health.StateUp, 0, 1)
+
+	results <- health.StateDown
+	assertCheckStatus(health.StateDown, health.StateDown, 1, 0)
+
+	results <- health.StateUp
+	results <- health.StateUp
+	assertCheckStatus(health.StateUp, health.StateUp, 0, 2)
+}
+
+func TestStateString(t *testing.T) {
+	assert.Equal(t, "down", health.StateDown.String())
+	assert.Equal(t, "warn", health.StateWarn.String())
+	assert.Equal(t, "up", health.StateUp.String())
+	assert.Equal(t, "State(10)", health.State(10).String())
+}
+
+func TestCheckStatistics(t *testing.T) {
+	healthMonitor := health.New()
+	ctx := context.Background()
+
+	results := make(chan health.State, 10)
+	checkFunc := func(ctx context.Context) health.Status {
+		state := <-results
+		if state == health.StateWarn {
+			// Exceed the timeout
+			<-ctx.Done()
+		}
+		return health.Status{State: state}
+	}
+	check := health.NewCheck("check", checkFunc)
+	check.TTL = time.Millisecond
+	check.Timeout = time.Millisecond * 20
+	healthMonitor.Monitor(ctx, check)
+
+	results <- health.StateUp
+	results <- health.StateDown
+	results <- health.StateWarn
+	results <- health.StateDown
+
+	// Wait for the results to be processed
+	time.Sleep(time.Millisecond * 100)
+
+	checkStatus := healthMonitor.Check().CheckStatuses[check.Name]
+	assert.Equal(t, uint64(4), checkStatus.Executions)
+	assert.Equal(t, uint64(2), checkStatus.Failures)
+	assert.Equal(t, uint64(1), checkStatus.Timeouts)
+	assert.False(t, checkStatus.LastSuccess.IsZero(), "Last success was not set")
+	assert.True(t, checkStatus.LastSuccess.Before(checkStatus.Timestamp), "Last success was updated by a failure")
+	assert.Less(t, checkStatus.Duration, time.Millisecond*20)
+
+	results <- health.StateWarn
+
+	// Wait for the result to be processed
+	time.Sleep(time.Millisecond * 50)
+
+	checkStatus = healthMonitor.Check().CheckStatuses[check.Name]
+	assert.Equal(t, uint64(5), checkStatus.Executions)
+	assert.Equal(t, uint64(2), checkStatus.Timeouts)
+	assert.Equal(t, checkStatus.Timestamp, checkStatus.LastSuccess)
+	assert.GreaterOrEqual(t, checkStatus.Duration, time.Millisecond*20)
+}
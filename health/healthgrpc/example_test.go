@@ -0,0 +1,34 @@
+package healthgrpc_test
+
+import (
+	"context"
+	"time"
+
+	"github.com/jaredpetersen/go-health/health"
+	"github.com/jaredpetersen/go-health/health/healthgrpc"
+	"google.golang.org/grpc"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+)
+
+func Example() {
+	// Create the health monitor that will be polling the resources.
+	healthMonitor := health.New()
+
+	// Prepare the context -- this can be used to stop async monitoring.
+	ctx := context.Background()
+
+	// Create your health checks.
+	dbHealthCheckFunc := func(ctx context.Context) health.Status {
+		return health.Status{State: health.StateUp}
+	}
+	dbHealthCheck := health.NewCheck("db", dbHealthCheckFunc)
+	dbHealthCheck.Timeout = time.Second * 2
+	dbHealthCheck.Groups = []string{health.GroupReadiness}
+	healthMonitor.Monitor(ctx, dbHealthCheck)
+
+	// Register the health server with your gRPC server. The "users" service is only serving when the readiness group
+	// is.
+	grpcServer := grpc.NewServer()
+	healthServer := healthgrpc.NewServer(healthMonitor, healthgrpc.WithServiceGroup("users", health.GroupReadiness))
+	healthpb.RegisterHealthServer(grpcServer, healthServer)
+}
@@ -0,0 +1,24 @@
+module github.com/jaredpetersen/go-health/health/healthgrpc
+
+go 1.17
+
+require (
+	github.com/jaredpetersen/go-health v1.0.0
+	github.com/stretchr/testify v1.7.0
+	google.golang.org/grpc v1.43.0
+)
+
+require (
+	github.com/davecgh/go-spew v1.1.1 // indirect
+	github.com/golang/protobuf v1.5.2 // indirect
+	github.com/pmezard/go-difflib v1.0.0 // indirect
+	golang.org/x/net v0.0.0-20210525063256-abc453219eb5 // indirect
+	golang.org/x/sys v0.0.0-20220114195835-da31bd327af9 // indirect
+	golang.org/x/text v0.3.6 // indirect
+	google.golang.org/genproto v0.0.0-20200825200019-8632dd797987 // indirect
+	google.golang.org/protobuf v1.26.0 // indirect
+	gopkg.in/yaml.v3 v3.0.0-20200313102051-9f266ea9e77c // indirect
+)
+
+// The healthgrpc module is developed alongside the health package.
+replace github.com/jaredpetersen/go-health => ../..
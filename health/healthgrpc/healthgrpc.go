@@ -0,0 +1,165 @@
+// Package healthgrpc provides an optional implementation of the gRPC Health Checking Protocol (grpc.health.v1.Health)
+// backed by a health monitor.
+package healthgrpc
+
+import (
+	"context"
+
+	"github.com/jaredpetersen/go-health/health"
+	"google.golang.org/grpc/codes"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/status"
+)
+
+// Server implements the gRPC Health Checking Protocol using the cached statuses of a health monitor.
+//
+// The empty service name represents the overall health of the server and is derived from the overall state of the
+// monitor. Additional services may be configured with WithServiceGroup, which derive their health from a group of
+// checks in the same way as Monitor.CheckGroup, or with WithService, which are SERVING only if every one of a list of
+// named checks is SERVING.
+//
+// Watch streams are driven by the monitor's state change events rather than by repeatedly polling the monitor.
+type Server struct {
+	healthpb.UnimplementedHealthServer
+
+	// mtr is the monitor that determines health.
+	mtr *health.Monitor
+	// services maps the name of a service to the checks that determine its health.
+	services map[string]service
+	// servingStates contains the states that are considered to be SERVING.
+	servingStates map[health.State]bool
+}
+
+// service describes the checks that determine the health of a named service.
+type service struct {
+	// group is the name of the group of checks. Empty if the service is determined by a list of checks instead.
+	group string
+	// checkNames contains the names of the checks. Only used if the service is not determined by a group.
+	checkNames []string
+}
+
+// Option is used to configure optional server behavior.
+type Option func(srv *Server)
+
+// WithServiceGroup configures a named service whose health is determined by the state of the group of checks with the
+// provided name, as reported by Monitor.CheckGroup. The group state takes NonCritical checks, the monitor's
+// aggregator, and latching into account, so the service agrees with the group published over HTTP. A group without
+// any checks is SERVING.
+func WithServiceGroup(name string, group string) Option {
+	return func(srv *Server) {
+		srv.services[name] = service{group: group}
+	}
+}
+
+// WithService configures a named service whose health is determined by the provided checks. The service is
+// NOT_SERVING if any of the checks are not serving or are not registered with the monitor. Each check is evaluated on
+// its own state, so NonCritical and the monitor's aggregator are not taken into account. Use WithServiceGroup to
+// apply them.
+func WithService(name string, checkNames ...string) Option {
+	return func(srv *Server) {
+		srv.services[name] = service{checkNames: checkNames}
+	}
+}
+
+// WithWarnNotServing configures StateWarn to be reported as NOT_SERVING instead of SERVING.
+func WithWarnNotServing() Option {
+	return func(srv *Server) {
+		srv.servingStates[health.StateWarn] = false
+	}
+}
+
+// NewServer creates a gRPC health server backed by the provided monitor. The return value will never be nil.
+func NewServer(mtr *health.Monitor, opts ...Option) *Server {
+	srv := &Server{
+		mtr:      mtr,
+		services: make(map[string]service),
+		servingStates: map[health.State]bool{
+			health.StateUp:   true,
+			health.StateWarn: true,
+		},
+	}
+
+	for _, opt := range opts {
+		opt(srv)
+	}
+
+	return srv
+}
+
+// Check returns the serving status of the requested service. A NotFound error is returned if the service is unknown.
+func (srv *Server) Check(ctx context.Context, req *healthpb.HealthCheckRequest) (*healthpb.HealthCheckResponse, error) {
+	servingStatus := srv.servingStatus(req.GetService())
+	if servingStatus == healthpb.HealthCheckResponse_SERVICE_UNKNOWN {
+		return nil, status.Error(codes.NotFound, "unknown service")
+	}
+
+	return &healthpb.HealthCheckResponse{Status: servingStatus}, nil
+}
+
+// Watch streams the serving status of the requested service, sending the current status immediately and then again
+// whenever it changes. Unknown services are reported as SERVICE_UNKNOWN rather than resulting in an error.
+func (srv *Server) Watch(req *healthpb.HealthCheckRequest, stream healthpb.Health_WatchServer) error {
+	sub := srv.mtr.Subscribe()
+	defer sub.Unsubscribe()
+
+	lastServingStatus := srv.servingStatus(req.GetService())
+	if err := stream.Send(&healthpb.HealthCheckResponse{Status: lastServingStatus}); err != nil {
+		return err
+	}
+
+	for {
+		select {
+		case <-stream.Context().Done():
+			return status.Error(codes.Canceled, "stream has ended")
+		case _, ok := <-sub.C:
+			if !ok {
+				return status.Error(codes.Unavailable, "health monitor subscription ended")
+			}
+
+			servingStatus := srv.servingStatus(req.GetService())
+			if servingStatus == lastServingStatus {
+				continue
+			}
+
+			if err := stream.Send(&healthpb.HealthCheckResponse{Status: servingStatus}); err != nil {
+				return err
+			}
+			lastServingStatus = servingStatus
+		}
+	}
+}
+
+// servingStatus determines the serving status of the service.
+func (srv *Server) servingStatus(name string) healthpb.HealthCheckResponse_ServingStatus {
+	if name == "" {
+		return srv.servingStatusForState(srv.mtr.Check().State)
+	}
+
+	svc, ok := srv.services[name]
+	if !ok {
+		return healthpb.HealthCheckResponse_SERVICE_UNKNOWN
+	}
+
+	if svc.group != "" {
+		return srv.servingStatusForState(srv.mtr.CheckGroup(svc.group).State)
+	}
+
+	monitorStatus := srv.mtr.Check()
+	for _, checkName := range svc.checkNames {
+		checkStatus, ok := monitorStatus.CheckStatuses[checkName]
+		if !ok || srv.servingStatusForState(checkStatus.Status.State) != healthpb.HealthCheckResponse_SERVING {
+			return healthpb.HealthCheckResponse_NOT_SERVING
+		}
+	}
+
+	return healthpb.HealthCheckResponse_SERVING
+}
+
+// servingStatusForState converts the state into a serving status.
+func (srv *Server) servingStatusForState(state health.State) healthpb.HealthCheckResponse_ServingStatus {
+	if srv.servingStates[state] {
+		return healthpb.HealthCheckResponse_SERVING
+	}
+
+	return healthpb.HealthCheckResponse_NOT_SERVING
+}
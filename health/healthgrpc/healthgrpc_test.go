@@ -0,0 +1,273 @@
+package healthgrpc_test
+
+import (
+	"context"
+	"net"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/jaredpetersen/go-health/health"
+	"github.com/jaredpetersen/go-health/health/healthgrpc"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+)
+
+// newHealthClient serves the health server over an in-memory connection and returns a client for it.
+func newHealthClient(t *testing.T, srv *healthgrpc.Server) healthpb.HealthClient {
+	listener := bufconn.Listen(1024 * 1024)
+
+	grpcServer := grpc.NewServer()
+	healthpb.RegisterHealthServer(grpcServer, srv)
+	go func() {
+		_ = grpcServer.Serve(listener)
+	}()
+	t.Cleanup(grpcServer.Stop)
+
+	dialer := func(ctx context.Context, address string) (net.Conn, error) {
+		return listener.DialContext(ctx)
+	}
+	conn, err := grpc.Dial("bufnet", grpc.WithContextDialer(dialer), grpc.WithInsecure())
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		conn.Close()
+	})
+
+	return healthpb.NewHealthClient(conn)
+}
+
+// newStateCheck creates a check that reports the most recent state sent on the channel.
+func newStateCheck(name string, states <-chan health.State) health.Check {
+	state := health.StateDown
+	checkFunc := func(ctx context.Context) health.Status {
+		select {
+		case state = <-states:
+		default:
+		}
+		return health.Status{State: state}
+	}
+
+	check := health.NewCheck(name, checkFunc)
+	check.TTL = time.Millisecond * 10
+
+	return check
+}
+
+func TestCheck(t *testing.T) {
+	healthMonitor := health.New()
+	ctx := context.Background()
+
+	dbStates := make(chan health.State, 1)
+	dbStates <- health.StateUp
+	cacheStates := make(chan health.State, 1)
+	cacheStates <- health.StateWarn
+	queueStates := make(chan health.State, 1)
+	queueStates <- health.StateDown
+	healthMonitor.Monitor(
+		ctx,
+		newStateCheck("db", dbStates),
+		newStateCheck("cache", cacheStates),
+		newStateCheck("queue", queueStates))
+
+	srv := healthgrpc.NewServer(
+		healthMonitor,
+		healthgrpc.WithService("users", "db", "cache"),
+		healthgrpc.WithService("orders", "db", "queue"),
+		healthgrpc.WithService("payments", "db", "missing"))
+	client := newHealthClient(t, srv)
+
+	// Wait for goroutines to kick in
+	time.Sleep(time.Millisecond * 100)
+
+	tests := []struct {
+		service       string
+		servingStatus healthpb.HealthCheckResponse_ServingStatus
+	}{
+		{service: "", servingStatus: healthpb.HealthCheckResponse_NOT_SERVING},
+		{service: "users", servingStatus: healthpb.HealthCheckResponse_SERVING},
+		{service: "orders", servingStatus: healthpb.HealthCheckResponse_NOT_SERVING},
+		{service: "payments", servingStatus: healthpb.HealthCheckResponse_NOT_SERVING},
+	}
+
+	for _, test := range tests {
+		t.Run(test.service, func(t *testing.T) {
+			res, err := client.Check(ctx, &healthpb.HealthCheckRequest{Service: test.service})
+			require.NoError(t, err)
+			assert.Equal(t, test.servingStatus, res.GetStatus())
+		})
+	}
+}
+
+func TestCheckServiceGroup(t *testing.T) {
+	healthMonitor := health.New()
+	ctx := context.Background()
+
+	dbStates := make(chan health.State, 1)
+	dbStates <- health.StateUp
+	dbCheck := newStateCheck("db", dbStates)
+	dbCheck.Groups = []string{health.GroupReadiness, "orders"}
+
+	cacheStates := make(chan health.State, 1)
+	cacheStates <- health.StateDown
+	cacheCheck := newStateCheck("cache", cacheStates)
+	cacheCheck.Groups = []string{health.GroupReadiness}
+	cacheCheck.NonCritical = true
+
+	queueStates := make(chan health.State, 1)
+	queueStates <- health.StateDown
+	queueCheck := newStateCheck("queue", queueStates)
+	queueCheck.Groups = []string{"orders"}
+
+	healthMonitor.Monitor(ctx, dbCheck, cacheCheck, queueCheck)
+
+	srv := healthgrpc.NewServer(
+		healthMonitor,
+		healthgrpc.WithServiceGroup("users", health.GroupReadiness),
+		healthgrpc.WithServiceGroup("orders", "orders"),
+		healthgrpc.WithServiceGroup("payments", "empty"))
+	client := newHealthClient(t, srv)
+
+	// Wait for goroutines to kick in
+	time.Sleep(time.Millisecond * 100)
+
+	tests := []struct {
+		service       string
+		servingStatus healthpb.HealthCheckResponse_ServingStatus
+	}{
+		// The non-critical cache check only degrades the group to StateWarn
+		{service: "users", servingStatus: healthpb.HealthCheckResponse_SERVING},
+		{service: "orders", servingStatus: healthpb.HealthCheckResponse_NOT_SERVING},
+		{service: "payments", servingStatus: healthpb.HealthCheckResponse_SERVING},
+	}
+
+	for _, test := range tests {
+		t.Run(test.service, func(t *testing.T) {
+			res, err := client.Check(ctx, &healthpb.HealthCheckRequest{Service: test.service})
+			require.NoError(t, err)
+			assert.Equal(t, test.servingStatus, res.GetStatus())
+		})
+	}
+}
+
+func TestCheckUnknownService(t *testing.T) {
+	srv := healthgrpc.NewServer(health.New())
+	client := newHealthClient(t, srv)
+
+	_, err := client.Check(context.Background(), &healthpb.HealthCheckRequest{Service: "unknown"})
+	assert.Equal(t, codes.NotFound, status.Code(err))
+}
+
+func TestCheckWarnNotServing(t *testing.T) {
+	healthMonitor := health.New()
+	ctx := context.Background()
+
+	states := make(chan health.State, 1)
+	states <- health.StateWarn
+	healthMonitor.Monitor(ctx, newStateCheck("db", states))
+
+	srv := healthgrpc.NewServer(
+		healthMonitor,
+		healthgrpc.WithService("users", "db"),
+		healthgrpc.WithWarnNotServing())
+	client := newHealthClient(t, srv)
+
+	// Wait for goroutines to kick in
+	time.Sleep(time.Millisecond * 100)
+
+	res, err := client.Check(ctx, &healthpb.HealthCheckRequest{})
+	require.NoError(t, err)
+	assert.Equal(t, healthpb.HealthCheckResponse_NOT_SERVING, res.GetStatus())
+
+	res, err = client.Check(ctx, &healthpb.HealthCheckRequest{Service: "users"})
+	require.NoError(t, err)
+	assert.Equal(t, healthpb.HealthCheckResponse_NOT_SERVING, res.GetStatus())
+}
+
+func TestWatch(t *testing.T) {
+	healthMonitor := health.New()
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	states := make(chan health.State)
+	healthMonitor.Monitor(ctx, newStateCheck("db", states))
+
+	srv := healthgrpc.NewServer(healthMonitor, healthgrpc.WithService("users", "db"))
+	client := newHealthClient(t, srv)
+
+	stream, err := client.Watch(ctx, &healthpb.HealthCheckRequest{Service: "users"})
+	require.NoError(t, err)
+
+	res, err := stream.Recv()
+	require.NoError(t, err)
+	assert.Equal(t, healthpb.HealthCheckResponse_NOT_SERVING, res.GetStatus())
+
+	states <- health.StateUp
+
+	res, err = stream.Recv()
+	require.NoError(t, err)
+	assert.Equal(t, healthpb.HealthCheckResponse_SERVING, res.GetStatus())
+
+	// Changes that do not affect the serving status are not sent
+	states <- health.StateWarn
+	states <- health.StateDown
+
+	res, err = stream.Recv()
+	require.NoError(t, err)
+	assert.Equal(t, healthpb.HealthCheckResponse_NOT_SERVING, res.GetStatus())
+}
+
+func TestWatchUnknownService(t *testing.T) {
+	srv := healthgrpc.NewServer(health.New())
+	client := newHealthClient(t, srv)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	stream, err := client.Watch(ctx, &healthpb.HealthCheckRequest{Service: "unknown"})
+	require.NoError(t, err)
+
+	res, err := stream.Recv()
+	require.NoError(t, err)
+	assert.Equal(t, healthpb.HealthCheckResponse_SERVICE_UNKNOWN, res.GetStatus())
+}
+
+func TestWatchStale(t *testing.T) {
+	healthMonitor := health.New()
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	var executions int32
+	checkFunc := func(ctx context.Context) health.Status {
+		if atomic.AddInt32(&executions, 1) > 1 {
+			<-ctx.Done()
+		}
+		return health.Status{State: health.StateUp}
+	}
+	check := health.NewCheck("db", checkFunc)
+	check.TTL = time.Millisecond * 10
+	check.MaxAge = time.Millisecond * 40
+	healthMonitor.Monitor(ctx, check)
+
+	// Wait for the first execution
+	time.Sleep(time.Millisecond * 20)
+
+	srv := healthgrpc.NewServer(healthMonitor, healthgrpc.WithService("users", "db"))
+	client := newHealthClient(t, srv)
+
+	stream, err := client.Watch(ctx, &healthpb.HealthCheckRequest{Service: "users"})
+	require.NoError(t, err)
+
+	res, err := stream.Recv()
+	require.NoError(t, err)
+	assert.Equal(t, healthpb.HealthCheckResponse_SERVING, res.GetStatus())
+
+	// The check is stuck, so the status goes stale
+	res, err = stream.Recv()
+	require.NoError(t, err)
+	assert.Equal(t, healthpb.HealthCheckResponse_NOT_SERVING, res.GetStatus())
+}
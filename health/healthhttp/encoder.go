@@ -0,0 +1,72 @@
+package healthhttp
+
+import (
+	"encoding/json"
+	"io"
+	"time"
+
+	"github.com/jaredpetersen/go-health/health"
+)
+
+// Encoder writes the health status to the response body.
+type Encoder interface {
+	// ContentType returns the media type of the encoded body.
+	ContentType() string
+	// Encode writes the health status to the writer. The status of every individual check should only be included if
+	// verbose is true.
+	Encode(w io.Writer, status health.MonitorStatus, verbose bool) error
+}
+
+// JSONEncoder encodes the health status as JSON. States are represented by their string value.
+//
+// Terse:
+//
+//	{"state":"up"}
+//
+// Verbose:
+//
+//	{"state":"up","checks":{"db":{"state":"up","details":{"connections":5},"timestamp":"2021-10-14T12:00:00Z"}}}
+//
+// Checks that have not executed yet are marked with "pending":true and checks with a stale status are marked with
+// "stale":true.
+type JSONEncoder struct{}
+
+// jsonMonitorStatus is the JSON representation of health.MonitorStatus.
+type jsonMonitorStatus struct {
+	State  string                     `json:"state"`
+	Checks map[string]jsonCheckStatus `json:"checks,omitempty"`
+}
+
+// jsonCheckStatus is the JSON representation of health.CheckStatus.
+type jsonCheckStatus struct {
+	State     string      `json:"state"`
+	Details   interface{} `json:"details,omitempty"`
+	Timestamp time.Time   `json:"timestamp"`
+	Pending   bool        `json:"pending,omitempty"`
+	Stale     bool        `json:"stale,omitempty"`
+}
+
+// ContentType returns the JSON media type.
+func (encoder JSONEncoder) ContentType() string {
+	return "application/json"
+}
+
+// Encode writes the health status to the writer as JSON.
+func (encoder JSONEncoder) Encode(w io.Writer, status health.MonitorStatus, verbose bool) error {
+	body := jsonMonitorStatus{State: status.State.String()}
+
+	if verbose {
+		body.Checks = make(map[string]jsonCheckStatus, len(status.CheckStatuses))
+		for checkName, checkStatus := range status.CheckStatuses {
+			body.Checks[checkName] = jsonCheckStatus{
+				State:     checkStatus.Status.State.String(),
+				Details:   checkStatus.Status.Details,
+				Timestamp: checkStatus.Timestamp,
+				Pending:   checkStatus.Pending,
+				Stale:     checkStatus.Stale,
+			}
+		}
+	}
+
+	return json.NewEncoder(w).Encode(body)
+}
@@ -0,0 +1,35 @@
+package healthhttp_test
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"github.com/jaredpetersen/go-health/health"
+	"github.com/jaredpetersen/go-health/health/healthhttp"
+)
+
+func Example() {
+	// Create the health monitor that will be polling the resources.
+	healthMonitor := health.New()
+
+	// Prepare the context -- this can be used to stop async monitoring.
+	ctx := context.Background()
+
+	// Create your health checks.
+	fooHealthCheckFunc := func(ctx context.Context) health.Status {
+		return health.Status{State: health.StateWarn}
+	}
+	fooHealthCheck := health.NewCheck("foo", fooHealthCheckFunc)
+	fooHealthCheck.Timeout = time.Second * 2
+	healthMonitor.Monitor(ctx, fooHealthCheck)
+
+	// Publish the health on the router of your choice, responding with 429 Too Many Requests when the application is
+	// healthy but has some concerns.
+	healthHandler := healthhttp.NewHandler(
+		healthMonitor,
+		healthhttp.WithStatusCode(health.StateWarn, http.StatusTooManyRequests))
+
+	mux := http.NewServeMux()
+	mux.Handle("/health", healthHandler)
+}
@@ -0,0 +1,127 @@
+// Package healthhttp provides an optional, router-agnostic HTTP handler that publishes the health determined by a
+// health monitor.
+package healthhttp
+
+import (
+	"bytes"
+	"net/http"
+	"strconv"
+
+	"github.com/jaredpetersen/go-health/health"
+)
+
+// Checker determines the health of the application. *health.Monitor satisfies this interface.
+type Checker interface {
+	// Check returns the latest health status.
+	Check() health.MonitorStatus
+}
+
+// Handler is an http.Handler that responds with the health of the application. The HTTP status code of the response is
+// derived from the overall state and the body is written by the configured encoder.
+//
+// Responses are terse by default and only include the overall state. Requests with a "verbose" query parameter set to
+// a true value, or left empty as in "/health?verbose", receive the status of every check as well.
+type Handler struct {
+	// checker determines the health of the application.
+	checker Checker
+	// statusCodes maps the overall state to the HTTP status code of the response.
+	statusCodes map[health.State]int
+	// encoder writes the response body.
+	encoder Encoder
+	// verbose indicates that responses include the status of every check unless the request says otherwise.
+	verbose bool
+}
+
+// Option is used to configure optional handler behavior.
+type Option func(handler *Handler)
+
+// WithStatusCode configures the HTTP status code that the handler responds with when the overall state matches the
+// provided state. For example, WithStatusCode(health.StateWarn, http.StatusTooManyRequests).
+func WithStatusCode(state health.State, statusCode int) Option {
+	return func(handler *Handler) {
+		handler.statusCodes[state] = statusCode
+	}
+}
+
+// WithEncoder configures the encoder used to write the response body. JSONEncoder is used by default.
+func WithEncoder(encoder Encoder) Option {
+	return func(handler *Handler) {
+		handler.encoder = encoder
+	}
+}
+
+// WithVerbose configures the handler to respond with the status of every check unless the request sets the "verbose"
+// query parameter to a false value.
+func WithVerbose() Option {
+	return func(handler *Handler) {
+		handler.verbose = true
+	}
+}
+
+// NewHandler creates an HTTP handler that responds with the health determined by the checker. The return value will
+// never be nil.
+//
+// By default, StateUp and StateWarn respond with 200 OK and StateDown responds with 503 Service Unavailable.
+func NewHandler(checker Checker, opts ...Option) *Handler {
+	handler := &Handler{
+		checker: checker,
+		statusCodes: map[health.State]int{
+			health.StateUp:   http.StatusOK,
+			health.StateWarn: http.StatusOK,
+			health.StateDown: http.StatusServiceUnavailable,
+		},
+		encoder: JSONEncoder{},
+	}
+
+	for _, opt := range opts {
+		opt(handler)
+	}
+
+	return handler
+}
+
+// ServeHTTP responds with the latest health status.
+func (handler *Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	status := handler.checker.Check()
+
+	// Encode before writing anything so that encoding failures may still be communicated with the status code
+	var body bytes.Buffer
+	if err := handler.encoder.Encode(&body, status, handler.isVerbose(r)); err != nil {
+		http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+		return
+	}
+
+	statusCode, ok := handler.statusCodes[status.State]
+	if !ok {
+		statusCode = http.StatusServiceUnavailable
+	}
+
+	w.Header().Set("Content-Type", handler.encoder.ContentType())
+	w.Header().Set("Cache-Control", "no-store")
+	w.Header().Set("Content-Length", strconv.Itoa(body.Len()))
+	w.WriteHeader(statusCode)
+
+	if r.Method != http.MethodHead {
+		_, _ = body.WriteTo(w)
+	}
+}
+
+// isVerbose determines if the response to the request should include the status of every check.
+func (handler *Handler) isVerbose(r *http.Request) bool {
+	query := r.URL.Query()
+	if _, ok := query["verbose"]; !ok {
+		return handler.verbose
+	}
+
+	value := query.Get("verbose")
+	if value == "" {
+		return true
+	}
+
+	verbose, err := strconv.ParseBool(value)
+	if err != nil {
+		return handler.verbose
+	}
+
+	return verbose
+}
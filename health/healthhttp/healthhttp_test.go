@@ -0,0 +1,192 @@
+package healthhttp_test
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/jaredpetersen/go-health/health"
+	"github.com/jaredpetersen/go-health/health/healthhttp"
+	"github.com/stretchr/testify/assert"
+)
+
+// staticChecker is a healthhttp.Checker that always returns the same status.
+type staticChecker health.MonitorStatus
+
+func (checker staticChecker) Check() health.MonitorStatus {
+	return health.MonitorStatus(checker)
+}
+
+// failingEncoder is a healthhttp.Encoder that always fails.
+type failingEncoder struct{}
+
+func (encoder failingEncoder) ContentType() string {
+	return "text/plain"
+}
+
+func (encoder failingEncoder) Encode(w io.Writer, status health.MonitorStatus, verbose bool) error {
+	return errors.New("encoding failed")
+}
+
+func newStatus(state health.State) staticChecker {
+	timestamp := time.Date(2021, time.October, 14, 12, 0, 0, 0, time.UTC)
+
+	return staticChecker{
+		State: state,
+		CheckStatuses: map[string]health.CheckStatus{
+			"db": {
+				Status:    health.Status{State: state, Details: map[string]int{"connections": 5}},
+				Timestamp: timestamp,
+			},
+		},
+	}
+}
+
+func TestHandlerStatusCodes(t *testing.T) {
+	tests := []struct {
+		state      health.State
+		statusCode int
+	}{
+		{state: health.StateUp, statusCode: http.StatusOK},
+		{state: health.StateWarn, statusCode: http.StatusOK},
+		{state: health.StateDown, statusCode: http.StatusServiceUnavailable},
+	}
+
+	for _, test := range tests {
+		t.Run(test.state.String(), func(t *testing.T) {
+			handler := healthhttp.NewHandler(newStatus(test.state))
+
+			res := httptest.NewRecorder()
+			handler.ServeHTTP(res, httptest.NewRequest(http.MethodGet, "/health", nil))
+
+			assert.Equal(t, test.statusCode, res.Code)
+			assert.Equal(t, "application/json", res.Header().Get("Content-Type"))
+			assert.Equal(t, "no-store", res.Header().Get("Cache-Control"))
+			assert.JSONEq(t, `{"state":"`+test.state.String()+`"}`, res.Body.String())
+		})
+	}
+}
+
+func TestHandlerWithStatusCode(t *testing.T) {
+	handler := healthhttp.NewHandler(
+		newStatus(health.StateWarn),
+		healthhttp.WithStatusCode(health.StateWarn, http.StatusTooManyRequests))
+
+	res := httptest.NewRecorder()
+	handler.ServeHTTP(res, httptest.NewRequest(http.MethodGet, "/health", nil))
+
+	assert.Equal(t, http.StatusTooManyRequests, res.Code)
+}
+
+func TestHandlerVerbose(t *testing.T) {
+	expectedBody := `{
+		"state": "up",
+		"checks": {
+			"db": {
+				"state": "up",
+				"details": {"connections": 5},
+				"timestamp": "2021-10-14T12:00:00Z"
+			}
+		}
+	}`
+
+	tests := []struct {
+		target  string
+		opts    []healthhttp.Option
+		verbose bool
+	}{
+		{target: "/health", verbose: false},
+		{target: "/health?verbose", verbose: true},
+		{target: "/health?verbose=true", verbose: true},
+		{target: "/health?verbose=1", verbose: true},
+		{target: "/health?verbose=false", verbose: false},
+		{target: "/health?verbose=nonsense", verbose: false},
+		{target: "/health", opts: []healthhttp.Option{healthhttp.WithVerbose()}, verbose: true},
+		{target: "/health?verbose=false", opts: []healthhttp.Option{healthhttp.WithVerbose()}, verbose: false},
+	}
+
+	for _, test := range tests {
+		t.Run(test.target, func(t *testing.T) {
+			handler := healthhttp.NewHandler(newStatus(health.StateUp), test.opts...)
+
+			res := httptest.NewRecorder()
+			handler.ServeHTTP(res, httptest.NewRequest(http.MethodGet, test.target, nil))
+
+			assert.Equal(t, http.StatusOK, res.Code)
+			if test.verbose {
+				assert.JSONEq(t, expectedBody, res.Body.String())
+			} else {
+				assert.JSONEq(t, `{"state":"up"}`, res.Body.String())
+			}
+		})
+	}
+}
+
+func TestHandlerHead(t *testing.T) {
+	handler := healthhttp.NewHandler(newStatus(health.StateDown))
+
+	res := httptest.NewRecorder()
+	handler.ServeHTTP(res, httptest.NewRequest(http.MethodHead, "/health", nil))
+
+	assert.Equal(t, http.StatusServiceUnavailable, res.Code)
+	assert.Equal(t, 0, res.Body.Len())
+	assert.NotEmpty(t, res.Header().Get("Content-Length"))
+}
+
+func TestHandlerEncodingFailure(t *testing.T) {
+	handler := healthhttp.NewHandler(newStatus(health.StateUp), healthhttp.WithEncoder(failingEncoder{}))
+
+	res := httptest.NewRecorder()
+	handler.ServeHTTP(res, httptest.NewRequest(http.MethodGet, "/health", nil))
+
+	assert.Equal(t, http.StatusInternalServerError, res.Code)
+}
+
+func TestHandlerMonitor(t *testing.T) {
+	healthMonitor := health.New()
+
+	handler := healthhttp.NewHandler(healthMonitor)
+
+	res := httptest.NewRecorder()
+	handler.ServeHTTP(res, httptest.NewRequest(http.MethodGet, "/health?verbose", nil))
+
+	var body map[string]interface{}
+	err := json.Unmarshal(res.Body.Bytes(), &body)
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusOK, res.Code)
+	assert.Equal(t, map[string]interface{}{"state": "up"}, body)
+}
+
+func TestHandlerVerbosePending(t *testing.T) {
+	checker := staticChecker{
+		State: health.StateDown,
+		CheckStatuses: map[string]health.CheckStatus{
+			"db": {
+				Status:  health.Status{State: health.StateDown},
+				Pending: true,
+			},
+		},
+	}
+	handler := healthhttp.NewHandler(checker)
+
+	res := httptest.NewRecorder()
+	handler.ServeHTTP(res, httptest.NewRequest(http.MethodGet, "/health?verbose", nil))
+
+	expected := `
+		{
+			"state": "down",
+			"checks": {
+				"db": {
+					"state": "down",
+					"timestamp": "0001-01-01T00:00:00Z",
+					"pending": true
+				}
+			}
+		}`
+	assert.Equal(t, http.StatusServiceUnavailable, res.Code)
+	assert.JSONEq(t, expected, res.Body.String())
+}
@@ -0,0 +1,132 @@
+package healthhttp
+
+import (
+	"encoding/json"
+	"io"
+	"time"
+
+	"github.com/jaredpetersen/go-health/health"
+)
+
+// Observation may be implemented by status details to supply the "observedValue" and "observedUnit" fields of the
+// IETF health check response format.
+type Observation interface {
+	// ObservedValue returns the value measured by the check, e.g. 250.
+	ObservedValue() interface{}
+	// ObservedUnit returns the unit of the observed value, e.g. "ms".
+	ObservedUnit() string
+}
+
+// Measurement may be implemented by status details to supply the measurement name of the IETF health check response
+// format. The check is then keyed as "<check name>:<measurement name>", e.g. "db:responseTime".
+type Measurement interface {
+	// MeasurementName returns the name of the measurement, e.g. "responseTime".
+	MeasurementName() string
+}
+
+// Outputter may be implemented by status details to supply the "output" field of the IETF health check response
+// format. Status details that implement error are used as the output as well.
+type Outputter interface {
+	// Output returns a human-readable explanation of the status, typically only for warn and fail.
+	Output() string
+}
+
+// IETFEncoder encodes the health status in the "Health Check Response Format for HTTP APIs" described by
+// https://datatracker.ietf.org/doc/html/draft-inadarei-api-health-check. StateUp, StateWarn, and StateDown are
+// represented as "pass", "warn", and "fail" respectively.
+//
+// Status details may implement Observation, Measurement, and Outputter to populate the optional fields of each check.
+type IETFEncoder struct {
+	// Version is the public version of the service. Optional.
+	Version string
+	// ReleaseID is the version of the service implementation. Optional.
+	ReleaseID string
+	// ServiceID is the unique identifier of the service. Optional.
+	ServiceID string
+	// Description is the human-friendly description of the service. Optional.
+	Description string
+}
+
+// ietfResponse is the top-level object of the IETF health check response format.
+type ietfResponse struct {
+	Status      string                 `json:"status"`
+	Version     string                 `json:"version,omitempty"`
+	ReleaseID   string                 `json:"releaseId,omitempty"`
+	ServiceID   string                 `json:"serviceId,omitempty"`
+	Description string                 `json:"description,omitempty"`
+	Checks      map[string][]ietfCheck `json:"checks,omitempty"`
+}
+
+// ietfCheck is the object describing an individual check in the IETF health check response format.
+type ietfCheck struct {
+	Status        string      `json:"status"`
+	ObservedValue interface{} `json:"observedValue,omitempty"`
+	ObservedUnit  string      `json:"observedUnit,omitempty"`
+	Time          string      `json:"time,omitempty"`
+	Output        string      `json:"output,omitempty"`
+}
+
+// ContentType returns the IETF health check response media type.
+func (encoder IETFEncoder) ContentType() string {
+	return "application/health+json"
+}
+
+// Encode writes the health status to the writer in the IETF health check response format.
+func (encoder IETFEncoder) Encode(w io.Writer, status health.MonitorStatus, verbose bool) error {
+	body := ietfResponse{
+		Status:      ietfStatus(status.State),
+		Version:     encoder.Version,
+		ReleaseID:   encoder.ReleaseID,
+		ServiceID:   encoder.ServiceID,
+		Description: encoder.Description,
+	}
+
+	if verbose && len(status.CheckStatuses) > 0 {
+		body.Checks = make(map[string][]ietfCheck, len(status.CheckStatuses))
+		for checkName, checkStatus := range status.CheckStatuses {
+			key := checkName
+			if measurement, ok := checkStatus.Status.Details.(Measurement); ok {
+				key = checkName + ":" + measurement.MeasurementName()
+			}
+
+			body.Checks[key] = append(body.Checks[key], newIETFCheck(checkStatus))
+		}
+	}
+
+	return json.NewEncoder(w).Encode(body)
+}
+
+// newIETFCheck converts the check status into the IETF health check response format.
+func newIETFCheck(checkStatus health.CheckStatus) ietfCheck {
+	check := ietfCheck{Status: ietfStatus(checkStatus.Status.State)}
+
+	if !checkStatus.Timestamp.IsZero() {
+		check.Time = checkStatus.Timestamp.Format(time.RFC3339Nano)
+	}
+
+	switch details := checkStatus.Status.Details.(type) {
+	case Outputter:
+		check.Output = details.Output()
+	case error:
+		check.Output = details.Error()
+	}
+
+	if observation, ok := checkStatus.Status.Details.(Observation); ok {
+		check.ObservedValue = observation.ObservedValue()
+		check.ObservedUnit = observation.ObservedUnit()
+	}
+
+	return check
+}
+
+// ietfStatus converts the state into the IETF health check response format status.
+func ietfStatus(state health.State) string {
+	switch state {
+	case health.StateUp:
+		return "pass"
+	case health.StateWarn:
+		return "warn"
+	default:
+		return "fail"
+	}
+}
@@ -0,0 +1,125 @@
+package healthhttp_test
+
+import (
+	"bytes"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/jaredpetersen/go-health/health"
+	"github.com/jaredpetersen/go-health/health/healthhttp"
+	"github.com/stretchr/testify/assert"
+)
+
+// responseTimeDetails implements all of the optional IETF status details interfaces.
+type responseTimeDetails struct {
+	ResponseTime time.Duration
+}
+
+func (details responseTimeDetails) ObservedValue() interface{} {
+	return details.ResponseTime.Milliseconds()
+}
+
+func (details responseTimeDetails) ObservedUnit() string {
+	return "ms"
+}
+
+func (details responseTimeDetails) MeasurementName() string {
+	return "responseTime"
+}
+
+func (details responseTimeDetails) Output() string {
+	return "slow response"
+}
+
+func TestIETFEncoderContentType(t *testing.T) {
+	assert.Equal(t, "application/health+json", healthhttp.IETFEncoder{}.ContentType())
+}
+
+func TestIETFEncoderTerse(t *testing.T) {
+	encoder := healthhttp.IETFEncoder{
+		Version:     "1",
+		ReleaseID:   "1.2.3",
+		ServiceID:   "f03e522f-1f44-4062-9b55-9587f91c9c41",
+		Description: "health of the example service",
+	}
+
+	var body bytes.Buffer
+	err := encoder.Encode(&body, newStatus(health.StateUp).Check(), false)
+	assert.NoError(t, err)
+
+	expectedBody := `{
+		"status": "pass",
+		"version": "1",
+		"releaseId": "1.2.3",
+		"serviceId": "f03e522f-1f44-4062-9b55-9587f91c9c41",
+		"description": "health of the example service"
+	}`
+	assert.JSONEq(t, expectedBody, body.String())
+}
+
+func TestIETFEncoderVerbose(t *testing.T) {
+	timestamp := time.Date(2021, time.October, 14, 12, 0, 0, 0, time.UTC)
+
+	status := health.MonitorStatus{
+		State: health.StateDown,
+		CheckStatuses: map[string]health.CheckStatus{
+			"api": {
+				Status: health.Status{
+					State:   health.StateWarn,
+					Details: responseTimeDetails{ResponseTime: time.Millisecond * 250},
+				},
+				Timestamp: timestamp,
+			},
+			"db": {
+				Status: health.Status{
+					State:   health.StateDown,
+					Details: errors.New("connection refused"),
+				},
+				Timestamp: timestamp,
+			},
+			"cache": {
+				Status: health.Status{State: health.StateUp},
+			},
+		},
+	}
+
+	var body bytes.Buffer
+	err := healthhttp.IETFEncoder{}.Encode(&body, status, true)
+	assert.NoError(t, err)
+
+	expectedBody := `{
+		"status": "fail",
+		"checks": {
+			"api:responseTime": [{
+				"status": "warn",
+				"observedValue": 250,
+				"observedUnit": "ms",
+				"time": "2021-10-14T12:00:00Z",
+				"output": "slow response"
+			}],
+			"db": [{
+				"status": "fail",
+				"time": "2021-10-14T12:00:00Z",
+				"output": "connection refused"
+			}],
+			"cache": [{
+				"status": "pass"
+			}]
+		}
+	}`
+	assert.JSONEq(t, expectedBody, body.String())
+}
+
+func TestHandlerIETFEncoder(t *testing.T) {
+	handler := healthhttp.NewHandler(newStatus(health.StateWarn), healthhttp.WithEncoder(healthhttp.IETFEncoder{}))
+
+	res := httptest.NewRecorder()
+	handler.ServeHTTP(res, httptest.NewRequest(http.MethodGet, "/health", nil))
+
+	assert.Equal(t, http.StatusOK, res.Code)
+	assert.Equal(t, "application/health+json", res.Header().Get("Content-Type"))
+	assert.JSONEq(t, `{"status":"warn"}`, res.Body.String())
+}
@@ -0,0 +1,27 @@
+module github.com/jaredpetersen/go-health/health/healthprometheus
+
+go 1.17
+
+require (
+	github.com/jaredpetersen/go-health v1.0.0
+	github.com/prometheus/client_golang v1.12.1
+	github.com/stretchr/testify v1.7.0
+)
+
+require (
+	github.com/beorn7/perks v1.0.1 // indirect
+	github.com/cespare/xxhash/v2 v2.1.2 // indirect
+	github.com/davecgh/go-spew v1.1.1 // indirect
+	github.com/golang/protobuf v1.5.2 // indirect
+	github.com/matttproud/golang_protobuf_extensions v1.0.1 // indirect
+	github.com/pmezard/go-difflib v1.0.0 // indirect
+	github.com/prometheus/client_model v0.2.0 // indirect
+	github.com/prometheus/common v0.32.1 // indirect
+	github.com/prometheus/procfs v0.7.3 // indirect
+	golang.org/x/sys v0.0.0-20220114195835-da31bd327af9 // indirect
+	google.golang.org/protobuf v1.26.0 // indirect
+	gopkg.in/yaml.v3 v3.0.0-20200313102051-9f266ea9e77c // indirect
+)
+
+// The healthprometheus module is developed alongside the health package.
+replace github.com/jaredpetersen/go-health => ../..
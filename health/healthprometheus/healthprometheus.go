@@ -0,0 +1,203 @@
+// Package healthprometheus provides an optional Prometheus collector that exposes the health determined by a health
+// monitor as metrics.
+package healthprometheus
+
+import (
+	"github.com/jaredpetersen/go-health/health"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// defaultNamespace is the prefix of the metric names unless configured otherwise.
+const defaultNamespace = "health"
+
+// Checker determines the health of the application. *health.Monitor satisfies this interface.
+type Checker interface {
+	// Check returns the latest health status.
+	Check() health.MonitorStatus
+}
+
+// poolStatser is implemented by checkers that limit how many check functions execute at the same time, like
+// *health.Monitor.
+type poolStatser interface {
+	// PoolStats returns statistics about the worker pool.
+	PoolStats() health.PoolStats
+}
+
+// Collector is a prometheus.Collector that exposes the cached check statuses of a health monitor as metrics. The
+// metrics are read from the monitor every time the collector is scraped. States are represented by their numeric
+// value: 0 for StateDown, 1 for StateWarn, and 2 for StateUp.
+//
+// The following metrics are exposed, assuming the default namespace:
+//
+//	health_state                                        Overall state of the monitor.
+//	health_check_state{check}                           State of the check.
+//	health_check_last_duration_seconds{check}           Duration of the most recent execution of the check.
+//	health_check_executions_total{check}                Number of times the check has been evaluated.
+//	health_check_failures_total{check}                  Number of executions that resulted in StateDown.
+//	health_check_timeouts_total{check}                  Number of executions that exceeded the check's timeout.
+//	health_check_last_success_timestamp_seconds{check}  Unix time of the most recent successful execution.
+//
+// If the checker is a monitor configured with health.WithMaxConcurrency, the worker pool is exposed as well:
+//
+//	health_pool_max_concurrency                         Max number of check functions executing at the same time.
+//	health_pool_running                                 Number of check functions holding a worker.
+//	health_pool_queued                                  Number of check functions waiting on a worker.
+//	health_pool_acquired_total                          Number of times a worker has been handed to a check function.
+//	health_pool_wait_seconds_total                      Total time check functions have spent waiting on a worker.
+type Collector struct {
+	// checker determines the health of the application.
+	checker Checker
+	// namespace is the prefix of the metric names.
+	namespace string
+	// The descriptors of the exposed metrics.
+	stateDesc        *prometheus.Desc
+	checkStateDesc   *prometheus.Desc
+	durationDesc     *prometheus.Desc
+	executionsDesc   *prometheus.Desc
+	failuresDesc     *prometheus.Desc
+	timeoutsDesc     *prometheus.Desc
+	lastSuccessDesc  *prometheus.Desc
+	poolMaxDesc      *prometheus.Desc
+	poolRunningDesc  *prometheus.Desc
+	poolQueuedDesc   *prometheus.Desc
+	poolAcquiredDesc *prometheus.Desc
+	poolWaitDesc     *prometheus.Desc
+}
+
+// Option is used to configure optional collector behavior.
+type Option func(collector *Collector)
+
+// WithNamespace configures the prefix of the metric names. Defaults to "health".
+func WithNamespace(namespace string) Option {
+	return func(collector *Collector) {
+		collector.namespace = namespace
+	}
+}
+
+// NewCollector creates a Prometheus collector that exposes the health determined by the checker. The return value will
+// never be nil.
+func NewCollector(checker Checker, opts ...Option) *Collector {
+	collector := &Collector{
+		checker:   checker,
+		namespace: defaultNamespace,
+	}
+
+	for _, opt := range opts {
+		opt(collector)
+	}
+
+	checkLabels := []string{"check"}
+	collector.stateDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(collector.namespace, "", "state"),
+		"Overall state of the health monitor (0 = down, 1 = warn, 2 = up).",
+		nil, nil)
+	collector.checkStateDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(collector.namespace, "check", "state"),
+		"State of the health check (0 = down, 1 = warn, 2 = up).",
+		checkLabels, nil)
+	collector.durationDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(collector.namespace, "check", "last_duration_seconds"),
+		"Duration of the most recent execution of the health check.",
+		checkLabels, nil)
+	collector.executionsDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(collector.namespace, "check", "executions_total"),
+		"Number of times the health check has been evaluated.",
+		checkLabels, nil)
+	collector.failuresDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(collector.namespace, "check", "failures_total"),
+		"Number of health check executions that resulted in the down state.",
+		checkLabels, nil)
+	collector.timeoutsDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(collector.namespace, "check", "timeouts_total"),
+		"Number of health check executions that exceeded the timeout.",
+		checkLabels, nil)
+	collector.lastSuccessDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(collector.namespace, "check", "last_success_timestamp_seconds"),
+		"Unix time of the most recent health check execution that did not result in the down state.",
+		checkLabels, nil)
+	collector.poolMaxDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(collector.namespace, "pool", "max_concurrency"),
+		"Max number of health check functions that may execute at the same time.",
+		nil, nil)
+	collector.poolRunningDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(collector.namespace, "pool", "running"),
+		"Number of health check functions currently holding a worker.",
+		nil, nil)
+	collector.poolQueuedDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(collector.namespace, "pool", "queued"),
+		"Number of health check functions currently waiting on a worker.",
+		nil, nil)
+	collector.poolAcquiredDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(collector.namespace, "pool", "acquired_total"),
+		"Number of times a worker has been handed to a health check function.",
+		nil, nil)
+	collector.poolWaitDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(collector.namespace, "pool", "wait_seconds_total"),
+		"Total time health check functions have spent waiting on a worker.",
+		nil, nil)
+
+	return collector
+}
+
+// Describe sends the descriptors of all of the metrics exposed by the collector.
+func (collector *Collector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- collector.stateDesc
+	ch <- collector.checkStateDesc
+	ch <- collector.durationDesc
+	ch <- collector.executionsDesc
+	ch <- collector.failuresDesc
+	ch <- collector.timeoutsDesc
+	ch <- collector.lastSuccessDesc
+	ch <- collector.poolMaxDesc
+	ch <- collector.poolRunningDesc
+	ch <- collector.poolQueuedDesc
+	ch <- collector.poolAcquiredDesc
+	ch <- collector.poolWaitDesc
+}
+
+// Collect sends the metrics for the latest health status.
+func (collector *Collector) Collect(ch chan<- prometheus.Metric) {
+	status := collector.checker.Check()
+
+	ch <- prometheus.MustNewConstMetric(collector.stateDesc, prometheus.GaugeValue, float64(status.State))
+
+	for checkName, checkStatus := range status.CheckStatuses {
+		ch <- prometheus.MustNewConstMetric(
+			collector.checkStateDesc, prometheus.GaugeValue, float64(checkStatus.Status.State), checkName)
+		ch <- prometheus.MustNewConstMetric(
+			collector.durationDesc, prometheus.GaugeValue, checkStatus.Duration.Seconds(), checkName)
+		ch <- prometheus.MustNewConstMetric(
+			collector.executionsDesc, prometheus.CounterValue, float64(checkStatus.Executions), checkName)
+		ch <- prometheus.MustNewConstMetric(
+			collector.failuresDesc, prometheus.CounterValue, float64(checkStatus.Failures), checkName)
+		ch <- prometheus.MustNewConstMetric(
+			collector.timeoutsDesc, prometheus.CounterValue, float64(checkStatus.Timeouts), checkName)
+
+		var lastSuccess float64
+		if !checkStatus.LastSuccess.IsZero() {
+			lastSuccess = float64(checkStatus.LastSuccess.UnixNano()) / 1e9
+		}
+		ch <- prometheus.MustNewConstMetric(collector.lastSuccessDesc, prometheus.GaugeValue, lastSuccess, checkName)
+	}
+
+	collector.collectPool(ch)
+}
+
+// collectPool sends the worker pool metrics if the checker limits concurrency.
+func (collector *Collector) collectPool(ch chan<- prometheus.Metric) {
+	statser, ok := collector.checker.(poolStatser)
+	if !ok {
+		return
+	}
+
+	stats := statser.PoolStats()
+	if stats.MaxConcurrency == 0 {
+		return
+	}
+
+	ch <- prometheus.MustNewConstMetric(collector.poolMaxDesc, prometheus.GaugeValue, float64(stats.MaxConcurrency))
+	ch <- prometheus.MustNewConstMetric(collector.poolRunningDesc, prometheus.GaugeValue, float64(stats.Running))
+	ch <- prometheus.MustNewConstMetric(collector.poolQueuedDesc, prometheus.GaugeValue, float64(stats.Queued))
+	ch <- prometheus.MustNewConstMetric(collector.poolAcquiredDesc, prometheus.CounterValue, float64(stats.Acquired))
+	ch <- prometheus.MustNewConstMetric(collector.poolWaitDesc, prometheus.CounterValue, stats.WaitTime.Seconds())
+}
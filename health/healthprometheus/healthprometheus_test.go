@@ -0,0 +1,131 @@
+package healthprometheus_test
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/jaredpetersen/go-health/health"
+	"github.com/jaredpetersen/go-health/health/healthprometheus"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+)
+
+// staticChecker is a healthprometheus.Checker that always returns the same status.
+type staticChecker health.MonitorStatus
+
+func (checker staticChecker) Check() health.MonitorStatus {
+	return health.MonitorStatus(checker)
+}
+
+func newStatus() staticChecker {
+	return staticChecker{
+		State: health.StateWarn,
+		CheckStatuses: map[string]health.CheckStatus{
+			"db": {
+				Status:      health.Status{State: health.StateWarn},
+				Timestamp:   time.Unix(1634212800, 0),
+				Duration:    time.Millisecond * 250,
+				Executions:  10,
+				Failures:    3,
+				Timeouts:    1,
+				LastSuccess: time.Unix(1634212800, 0),
+			},
+			"cache": {
+				Status:     health.Status{State: health.StateDown},
+				Executions: 2,
+				Failures:   2,
+			},
+		},
+	}
+}
+
+func TestCollector(t *testing.T) {
+	collector := healthprometheus.NewCollector(newStatus())
+
+	expected := `
+		# HELP health_check_executions_total Number of times the health check has been evaluated.
+		# TYPE health_check_executions_total counter
+		health_check_executions_total{check="cache"} 2
+		health_check_executions_total{check="db"} 10
+		# HELP health_check_failures_total Number of health check executions that resulted in the down state.
+		# TYPE health_check_failures_total counter
+		health_check_failures_total{check="cache"} 2
+		health_check_failures_total{check="db"} 3
+		# HELP health_check_last_duration_seconds Duration of the most recent execution of the health check.
+		# TYPE health_check_last_duration_seconds gauge
+		health_check_last_duration_seconds{check="cache"} 0
+		health_check_last_duration_seconds{check="db"} 0.25
+		# HELP health_check_last_success_timestamp_seconds Unix time of the most recent health check execution that did not result in the down state.
+		# TYPE health_check_last_success_timestamp_seconds gauge
+		health_check_last_success_timestamp_seconds{check="cache"} 0
+		health_check_last_success_timestamp_seconds{check="db"} 1.6342128e+09
+		# HELP health_check_state State of the health check (0 = down, 1 = warn, 2 = up).
+		# TYPE health_check_state gauge
+		health_check_state{check="cache"} 0
+		health_check_state{check="db"} 1
+		# HELP health_check_timeouts_total Number of health check executions that exceeded the timeout.
+		# TYPE health_check_timeouts_total counter
+		health_check_timeouts_total{check="cache"} 0
+		health_check_timeouts_total{check="db"} 1
+		# HELP health_state Overall state of the health monitor (0 = down, 1 = warn, 2 = up).
+		# TYPE health_state gauge
+		health_state 1
+	`
+
+	err := testutil.CollectAndCompare(collector, strings.NewReader(expected))
+	assert.NoError(t, err)
+}
+
+func TestCollectorNamespace(t *testing.T) {
+	collector := healthprometheus.NewCollector(newStatus(), healthprometheus.WithNamespace("myapp"))
+
+	expected := `
+		# HELP myapp_state Overall state of the health monitor (0 = down, 1 = warn, 2 = up).
+		# TYPE myapp_state gauge
+		myapp_state 1
+	`
+
+	err := testutil.CollectAndCompare(collector, strings.NewReader(expected), "myapp_state")
+	assert.NoError(t, err)
+}
+
+func TestCollectorRegister(t *testing.T) {
+	registry := prometheus.NewPedanticRegistry()
+
+	err := registry.Register(healthprometheus.NewCollector(health.New()))
+	assert.NoError(t, err)
+
+	metricFamilies, err := registry.Gather()
+	assert.NoError(t, err)
+	assert.Equal(t, 1, len(metricFamilies))
+	assert.Equal(t, "health_state", metricFamilies[0].GetName())
+}
+
+func TestCollectorPool(t *testing.T) {
+	collector := healthprometheus.NewCollector(health.New(health.WithMaxConcurrency(4)))
+
+	expected := `
+		# HELP health_pool_acquired_total Number of times a worker has been handed to a health check function.
+		# TYPE health_pool_acquired_total counter
+		health_pool_acquired_total 0
+		# HELP health_pool_max_concurrency Max number of health check functions that may execute at the same time.
+		# TYPE health_pool_max_concurrency gauge
+		health_pool_max_concurrency 4
+		# HELP health_pool_queued Number of health check functions currently waiting on a worker.
+		# TYPE health_pool_queued gauge
+		health_pool_queued 0
+		# HELP health_pool_running Number of health check functions currently holding a worker.
+		# TYPE health_pool_running gauge
+		health_pool_running 0
+		# HELP health_pool_wait_seconds_total Total time health check functions have spent waiting on a worker.
+		# TYPE health_pool_wait_seconds_total counter
+		health_pool_wait_seconds_total 0
+	`
+
+	err := testutil.CollectAndCompare(collector, strings.NewReader(expected),
+		"health_pool_acquired_total", "health_pool_max_concurrency", "health_pool_queued", "health_pool_running",
+		"health_pool_wait_seconds_total")
+	assert.NoError(t, err)
+}
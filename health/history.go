@@ -0,0 +1,96 @@
+package health
+
+import (
+	"fmt"
+	"time"
+)
+
+// Execution is a record of a single evaluation of a check.
+type Execution struct {
+	// State is the state returned by the check function.
+	State State
+	// Details are the additional details returned by the check function.
+	Details interface{}
+	// Start is the time that the execution started.
+	Start time.Time
+	// Duration is the time that the execution took.
+	Duration time.Duration
+	// TimedOut indicates that the execution exceeded the check's timeout.
+	TimedOut bool
+	// Panicked indicates that the check function panicked.
+	Panicked bool
+}
+
+// executionHistory is a fixed size ring buffer of the most recent executions of a check.
+type executionHistory struct {
+	// executions contains the retained executions. Once full, the oldest execution is overwritten.
+	executions []Execution
+	// next is the index that the next execution will be written to.
+	next int
+	// full indicates that the buffer has wrapped around.
+	full bool
+}
+
+// newExecutionHistory creates an execution history that retains the provided number of executions.
+func newExecutionHistory(size int) *executionHistory {
+	if size < 0 {
+		size = 0
+	}
+
+	return &executionHistory{executions: make([]Execution, size)}
+}
+
+// add records the execution, overwriting the oldest one if the history is full.
+func (history *executionHistory) add(execution Execution) {
+	if len(history.executions) == 0 {
+		return
+	}
+
+	history.executions[history.next] = execution
+	history.next = (history.next + 1) % len(history.executions)
+	if history.next == 0 {
+		history.full = true
+	}
+}
+
+// list returns a copy of the retained executions ordered from oldest to newest.
+func (history *executionHistory) list() []Execution {
+	if !history.full {
+		executions := make([]Execution, history.next)
+		copy(executions, history.executions[:history.next])
+		return executions
+	}
+
+	executions := make([]Execution, 0, len(history.executions))
+	executions = append(executions, history.executions[history.next:]...)
+	executions = append(executions, history.executions[:history.next]...)
+
+	return executions
+}
+
+// newExecution creates a record of the execution from its result.
+func newExecution(result executionResult) Execution {
+	return Execution{
+		State:    result.checkStatus.Status.State,
+		Details:  result.checkStatus.Status.Details,
+		Start:    result.checkStatus.Timestamp.Add(-result.checkStatus.Duration),
+		Duration: result.checkStatus.Duration,
+		TimedOut: result.timedOut,
+		Panicked: result.panicked,
+	}
+}
+
+// History returns the most recent executions of the check with the provided name, ordered from oldest to newest. The
+// number of executions retained is configured on the check via HistorySize. The history is reset when the check is
+// replaced. An error wrapping ErrCheckNotFound is returned if no check with the provided name is registered.
+func (mtr *Monitor) History(name string) ([]Execution, error) {
+	mtr.mtx.RLock()
+	defer mtr.mtx.RUnlock()
+
+	runner, ok := mtr.runners[name]
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", ErrCheckNotFound, name)
+	}
+
+	return runner.history.list(), nil
+}
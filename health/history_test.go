@@ -0,0 +1,114 @@
+package health_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/jaredpetersen/go-health/health"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHistory(t *testing.T) {
+	healthMonitor := health.New()
+	ctx := context.Background()
+
+	results := make(chan health.State, 10)
+	checkFunc := func(ctx context.Context) health.Status {
+		state := <-results
+		if state == health.StateWarn {
+			panic("something went wrong")
+		}
+		return health.Status{State: state, Details: "details"}
+	}
+	check := health.NewCheck("check", checkFunc)
+	check.TTL = time.Millisecond
+	check.HistorySize = 3
+	healthMonitor.Monitor(ctx, check)
+
+	before := time.Now()
+
+	results <- health.StateUp
+	results <- health.StateDown
+
+	// Wait for the results to be processed
+	time.Sleep(time.Millisecond * 50)
+
+	history, err := healthMonitor.History(check.Name)
+	assert.NoError(t, err)
+	if assert.Equal(t, 2, len(history)) {
+		assert.Equal(t, health.StateUp, history[0].State)
+		assert.Equal(t, "details", history[0].Details)
+		assert.True(t, !history[0].Start.Before(before), "Execution start time is too early")
+		assert.False(t, history[0].TimedOut)
+		assert.False(t, history[0].Panicked)
+		assert.Equal(t, health.StateDown, history[1].State)
+		assert.False(t, history[1].Start.Before(history[0].Start), "Executions are not in order")
+	}
+
+	// Overflow the history
+	results <- health.StateWarn
+	results <- health.StateUp
+
+	// Wait for the results to be processed
+	time.Sleep(time.Millisecond * 50)
+
+	history, err = healthMonitor.History(check.Name)
+	assert.NoError(t, err)
+	if assert.Equal(t, 3, len(history)) {
+		assert.Equal(t, health.StateDown, history[0].State)
+		assert.Equal(t, health.StateDown, history[1].State)
+		assert.True(t, history[1].Panicked)
+		assert.Equal(t, health.StateUp, history[2].State)
+	}
+}
+
+func TestHistoryTimedOut(t *testing.T) {
+	healthMonitor := health.New()
+	ctx := context.Background()
+
+	checkFunc := func(ctx context.Context) health.Status {
+		<-ctx.Done()
+		return health.Status{State: health.StateDown}
+	}
+	check := health.NewCheck("check", checkFunc)
+	check.TTL = time.Hour
+	check.Timeout = time.Millisecond * 10
+	check.HistorySize = 5
+	healthMonitor.Monitor(ctx, check)
+
+	// Wait for goroutines to kick in
+	time.Sleep(time.Millisecond * 50)
+
+	history, err := healthMonitor.History(check.Name)
+	assert.NoError(t, err)
+	if assert.Equal(t, 1, len(history)) {
+		assert.True(t, history[0].TimedOut)
+		assert.GreaterOrEqual(t, history[0].Duration, check.Timeout)
+	}
+}
+
+func TestHistoryDisabled(t *testing.T) {
+	healthMonitor := health.New()
+	ctx := context.Background()
+
+	checkFunc := func(ctx context.Context) health.Status {
+		return health.Status{State: health.StateUp}
+	}
+	check := health.NewCheck("check", checkFunc)
+	healthMonitor.Monitor(ctx, check)
+
+	// Wait for goroutines to kick in
+	time.Sleep(time.Millisecond * 50)
+
+	history, err := healthMonitor.History(check.Name)
+	assert.NoError(t, err)
+	assert.Empty(t, history)
+}
+
+func TestHistoryNotFound(t *testing.T) {
+	healthMonitor := health.New()
+
+	_, err := healthMonitor.History("check")
+	assert.ErrorIs(t, err, health.ErrCheckNotFound)
+}
@@ -0,0 +1,491 @@
+package health
+
+import (
+	"container/heap"
+	"sync"
+	"time"
+)
+
+// PoolStats contains statistics about the worker pool that limits how many check functions execute at the same time.
+type PoolStats struct {
+	// MaxConcurrency is the max number of check functions that may execute at the same time. Zero if the monitor does
+	// not limit concurrency.
+	MaxConcurrency int
+	// Running is the number of check functions currently holding a worker, including abandoned executions that have
+	// not yet returned.
+	Running int
+	// Queued is the number of check functions that are due and currently waiting on a worker.
+	Queued int
+	// Acquired is the total number of times a worker has been handed to a check function.
+	Acquired uint64
+	// WaitTime is the total time that check functions have spent waiting on a worker.
+	WaitTime time.Duration
+}
+
+// WithMaxConcurrency configures the monitor to execute at most n check functions at the same time, across all checks.
+// Executions that are due while all of the workers are busy wait in a queue, with critical checks taking priority over
+// checks marked as NonCritical. Checks of the same priority are executed in the order that they became due. Values
+// less than one do not limit concurrency, which is the default.
+//
+// Instead of starting a goroutine for every check, the monitor schedules all of the checks on a single timer queue
+// that feeds n worker goroutines, so the number of goroutines does not grow with the number of checks. The workers
+// exit while no checks are registered.
+//
+// The time spent waiting on a worker counts towards the cadence of the check but not towards its timeout. When the
+// timeout is enforced, an abandoned execution holds on to its worker until the check function returns, regardless of
+// the abandon limit, so check functions that never return permanently reduce the number of workers.
+func WithMaxConcurrency(n int) Option {
+	return func(mtr *Monitor) {
+		if n < 1 {
+			mtr.pool = nil
+			return
+		}
+
+		mtr.pool = newWorkerPool(n, mtr.executeScheduled)
+	}
+}
+
+// PoolStats returns statistics about the worker pool configured with WithMaxConcurrency. The zero value is returned if
+// the monitor does not limit concurrency.
+func (mtr *Monitor) PoolStats() PoolStats {
+	if mtr.pool == nil {
+		return PoolStats{}
+	}
+
+	return mtr.pool.stats()
+}
+
+// poolEntryState indicates where a check scheduled on the pool currently is.
+type poolEntryState int
+
+const (
+	// poolEntryWaiting indicates that the check is waiting on the timer queue until its next execution is due.
+	poolEntryWaiting poolEntryState = iota
+	// poolEntryQueued indicates that the check is due and waiting on a worker.
+	poolEntryQueued
+	// poolEntryExecuting indicates that a worker is executing the check.
+	poolEntryExecuting
+)
+
+// poolEntry is a check scheduled on the pool.
+type poolEntry struct {
+	// runner is the runner of the check.
+	runner *checkRunner
+	// state indicates where the check currently is.
+	state poolEntryState
+	// due is the time that the next execution is due.
+	due time.Time
+	// index is the position of the entry in the timer queue while waiting.
+	index int
+	// enqueued is the time that the check started waiting on a worker.
+	enqueued time.Time
+	// refreshed indicates that the next execution was requested via Refresh.
+	refreshed bool
+	// cancelled indicates that the check was cancelled while it was executing.
+	cancelled bool
+}
+
+// timerQueue orders the waiting entries by the time that their next execution is due. It implements heap.Interface.
+type timerQueue []*poolEntry
+
+func (queue timerQueue) Len() int {
+	return len(queue)
+}
+
+func (queue timerQueue) Less(i, j int) bool {
+	return queue[i].due.Before(queue[j].due)
+}
+
+func (queue timerQueue) Swap(i, j int) {
+	queue[i], queue[j] = queue[j], queue[i]
+	queue[i].index = i
+	queue[j].index = j
+}
+
+func (queue *timerQueue) Push(x interface{}) {
+	entry := x.(*poolEntry)
+	entry.index = len(*queue)
+	*queue = append(*queue, entry)
+}
+
+func (queue *timerQueue) Pop() interface{} {
+	old := *queue
+	entry := old[len(old)-1]
+	old[len(old)-1] = nil
+	*queue = old[:len(old)-1]
+
+	return entry
+}
+
+// contextWatch cancels the checks registered with a context once that context is done.
+type contextWatch struct {
+	// runners contains the runners of the checks registered with the context.
+	runners map[*checkRunner]struct{}
+	// stop is closed to stop watching the context once no checks registered with it remain.
+	stop chan struct{}
+}
+
+// workerPool schedules the checks on a single timer queue and executes them on a fixed number of workers. The due
+// checks wait in a queue for a worker, with critical checks taking priority over non-critical ones.
+type workerPool struct {
+	// size is the max number of check functions executing at the same time, which is also the number of workers.
+	size int
+	// execute executes the check function of the runner and schedules the next execution with reschedule.
+	execute func(runner *checkRunner, refreshed bool)
+	// entries contains the entry of every check scheduled on the pool.
+	entries map[*checkRunner]*poolEntry
+	// timers contains the entries that are waiting until their next execution is due.
+	timers timerQueue
+	// critical contains the due entries belonging to critical checks, in the order that they became due.
+	critical []*poolEntry
+	// nonCritical contains the due entries belonging to non-critical checks, in the order that they became due.
+	nonCritical []*poolEntry
+	// watches contains the contexts that checks were registered with, keyed by their done channel.
+	watches map[<-chan struct{}]*contextWatch
+	// running is the number of workers that have been handed out, including to abandoned executions.
+	running int
+	// workers is the number of worker goroutines that are running.
+	workers int
+	// dispatching indicates that the goroutine moving due entries from the timer queue to the workers is running.
+	dispatching bool
+	// wake is signaled whenever the timer queue changes so that the dispatching goroutine re-evaluates it.
+	wake chan struct{}
+	// acquired is the total number of workers that have been handed out.
+	acquired uint64
+	// waitTime is the total time spent waiting on a worker.
+	waitTime time.Duration
+	// mtx coordinates access to all of the above.
+	mtx sync.Mutex
+	// available is signaled whenever an entry becomes due or a worker is released.
+	available *sync.Cond
+}
+
+// newWorkerPool creates a worker pool with the provided number of workers that executes checks with the provided
+// function.
+func newWorkerPool(size int, execute func(runner *checkRunner, refreshed bool)) *workerPool {
+	pool := &workerPool{
+		size:    size,
+		execute: execute,
+		entries: make(map[*checkRunner]*poolEntry),
+		watches: make(map[<-chan struct{}]*contextWatch),
+		wake:    make(chan struct{}, 1),
+	}
+	pool.available = sync.NewCond(&pool.mtx)
+
+	return pool
+}
+
+// add schedules the first execution of the runner's check after the provided delay, starting the workers if necessary.
+// The runner is cancelled once the context that its check was registered with is done.
+func (pool *workerPool) add(runner *checkRunner, delay time.Duration) {
+	pool.mtx.Lock()
+	defer pool.mtx.Unlock()
+
+	entry := &poolEntry{runner: runner}
+	pool.entries[runner] = entry
+	pool.wait(entry, time.Now().Add(delay))
+	pool.watch(runner)
+
+	if !pool.dispatching {
+		pool.dispatching = true
+		go pool.dispatch()
+	}
+
+	for pool.workers < pool.size {
+		pool.workers++
+		go pool.work()
+	}
+}
+
+// remove stops scheduling the runner's check. True is returned if the runner may be retired right away; otherwise
+// the check is executing and the runner is retired once the execution completes. False is also returned if the check
+// was already removed.
+func (pool *workerPool) remove(runner *checkRunner) bool {
+	pool.mtx.Lock()
+	defer pool.mtx.Unlock()
+
+	entry, ok := pool.entries[runner]
+	if !ok {
+		return false
+	}
+
+	switch entry.state {
+	case poolEntryWaiting:
+		heap.Remove(&pool.timers, entry.index)
+	case poolEntryQueued:
+		pool.critical = removeEntry(pool.critical, entry)
+		pool.nonCritical = removeEntry(pool.nonCritical, entry)
+	case poolEntryExecuting:
+		entry.cancelled = true
+		return false
+	}
+
+	pool.delete(entry)
+
+	return true
+}
+
+// reschedule schedules the next execution of the runner's check at the provided time once an execution has completed,
+// or right away if a refresh was requested in the meantime. False is returned if the check was removed during the
+// execution, in which case the runner must be retired.
+func (pool *workerPool) reschedule(runner *checkRunner, due time.Time) bool {
+	pool.mtx.Lock()
+	defer pool.mtx.Unlock()
+
+	entry := pool.entries[runner]
+	if entry.cancelled {
+		pool.delete(entry)
+		return false
+	}
+
+	if entry.refreshed {
+		pool.enqueue(entry)
+		return true
+	}
+
+	pool.wait(entry, due)
+
+	return true
+}
+
+// refresh makes the next execution of the runner's check due right away. Nothing happens if the check has been removed.
+func (pool *workerPool) refresh(runner *checkRunner) {
+	pool.mtx.Lock()
+	defer pool.mtx.Unlock()
+
+	entry, ok := pool.entries[runner]
+	if !ok {
+		return
+	}
+
+	entry.refreshed = true
+	if entry.state == poolEntryWaiting {
+		heap.Remove(&pool.timers, entry.index)
+		pool.enqueue(entry)
+	}
+}
+
+// release returns a worker to the pool so that it may execute the next due check.
+func (pool *workerPool) release() {
+	pool.mtx.Lock()
+	defer pool.mtx.Unlock()
+
+	pool.running--
+	pool.available.Signal()
+}
+
+// stats returns a snapshot of the pool statistics.
+func (pool *workerPool) stats() PoolStats {
+	pool.mtx.Lock()
+	defer pool.mtx.Unlock()
+
+	return PoolStats{
+		MaxConcurrency: pool.size,
+		Running:        pool.running,
+		Queued:         len(pool.critical) + len(pool.nonCritical),
+		Acquired:       pool.acquired,
+		WaitTime:       pool.waitTime,
+	}
+}
+
+// wait puts the entry on the timer queue until the provided time. The pool mutex must be held by the caller.
+func (pool *workerPool) wait(entry *poolEntry, due time.Time) {
+	entry.state = poolEntryWaiting
+	entry.due = due
+	heap.Push(&pool.timers, entry)
+	pool.signalWake()
+}
+
+// enqueue puts the entry in line for a worker. The pool mutex must be held by the caller.
+func (pool *workerPool) enqueue(entry *poolEntry) {
+	entry.state = poolEntryQueued
+	entry.enqueued = time.Now()
+	if entry.runner.check.NonCritical {
+		pool.nonCritical = append(pool.nonCritical, entry)
+	} else {
+		pool.critical = append(pool.critical, entry)
+	}
+	pool.available.Signal()
+}
+
+// dequeue takes the next entry in line for a worker, giving priority to critical checks. Nil is returned if no entries
+// are due. The pool mutex must be held by the caller.
+func (pool *workerPool) dequeue() *poolEntry {
+	var entry *poolEntry
+	if len(pool.critical) > 0 {
+		entry = pool.critical[0]
+		pool.critical = pool.critical[1:]
+	} else if len(pool.nonCritical) > 0 {
+		entry = pool.nonCritical[0]
+		pool.nonCritical = pool.nonCritical[1:]
+	}
+
+	return entry
+}
+
+// delete stops tracking the entry. The workers and the dispatching goroutine exit once no entries remain. The pool
+// mutex must be held by the caller.
+func (pool *workerPool) delete(entry *poolEntry) {
+	delete(pool.entries, entry.runner)
+	pool.unwatch(entry.runner)
+
+	if len(pool.entries) == 0 {
+		pool.available.Broadcast()
+		pool.signalWake()
+	}
+}
+
+// signalWake notifies the dispatching goroutine that the timer queue has changed. The pool mutex must be held by the
+// caller.
+func (pool *workerPool) signalWake() {
+	select {
+	case pool.wake <- struct{}{}:
+	default:
+	}
+}
+
+// dispatch moves the entries from the timer queue to the workers once they are due until no entries remain.
+func (pool *workerPool) dispatch() {
+	timer := time.NewTimer(0)
+	defer timer.Stop()
+
+	pool.mtx.Lock()
+	for {
+		if len(pool.entries) == 0 {
+			pool.dispatching = false
+			pool.mtx.Unlock()
+			return
+		}
+
+		now := time.Now()
+		for len(pool.timers) > 0 && !pool.timers[0].due.After(now) {
+			pool.enqueue(heap.Pop(&pool.timers).(*poolEntry))
+		}
+
+		var timerC <-chan time.Time
+		if len(pool.timers) > 0 {
+			if !timer.Stop() {
+				select {
+				case <-timer.C:
+				default:
+				}
+			}
+			timer.Reset(pool.timers[0].due.Sub(now))
+			timerC = timer.C
+		}
+		pool.mtx.Unlock()
+
+		select {
+		case <-timerC:
+		case <-pool.wake:
+		}
+
+		pool.mtx.Lock()
+	}
+}
+
+// work executes the due entries one at a time whenever fewer than the max number of workers are running, until no
+// entries remain.
+func (pool *workerPool) work() {
+	pool.mtx.Lock()
+	for {
+		if len(pool.entries) == 0 {
+			pool.workers--
+			pool.mtx.Unlock()
+			return
+		}
+
+		if pool.running >= pool.size {
+			pool.available.Wait()
+			continue
+		}
+
+		entry := pool.dequeue()
+		if entry == nil {
+			pool.available.Wait()
+			continue
+		}
+
+		entry.state = poolEntryExecuting
+		refreshed := entry.refreshed
+		entry.refreshed = false
+		pool.running++
+		pool.acquired++
+		pool.waitTime += time.Since(entry.enqueued)
+		pool.mtx.Unlock()
+
+		// The worker is released by the execution once the check function returns
+		pool.execute(entry.runner, refreshed)
+
+		pool.mtx.Lock()
+	}
+}
+
+// watch cancels the runner once the context that its check was registered with is done. A single goroutine watches
+// each context, no matter how many checks were registered with it. The pool mutex must be held by the caller.
+func (pool *workerPool) watch(runner *checkRunner) {
+	done := runner.ctx.Done()
+	if done == nil {
+		// The context is never done
+		return
+	}
+
+	watch, ok := pool.watches[done]
+	if !ok {
+		watch = &contextWatch{runners: make(map[*checkRunner]struct{}), stop: make(chan struct{})}
+		pool.watches[done] = watch
+		go pool.watchContext(done, watch)
+	}
+
+	watch.runners[runner] = struct{}{}
+}
+
+// unwatch stops watching the context of the runner's check, stopping the goroutine watching the context if no other
+// checks were registered with it. The pool mutex must be held by the caller.
+func (pool *workerPool) unwatch(runner *checkRunner) {
+	done := runner.ctx.Done()
+	watch, ok := pool.watches[done]
+	if !ok {
+		return
+	}
+
+	delete(watch.runners, runner)
+	if len(watch.runners) == 0 {
+		delete(pool.watches, done)
+		close(watch.stop)
+	}
+}
+
+// watchContext cancels the watched runners once the done channel of their context is closed.
+func (pool *workerPool) watchContext(done <-chan struct{}, watch *contextWatch) {
+	select {
+	case <-done:
+	case <-watch.stop:
+		return
+	}
+
+	pool.mtx.Lock()
+	if pool.watches[done] == watch {
+		delete(pool.watches, done)
+	}
+	runners := make([]*checkRunner, 0, len(watch.runners))
+	for runner := range watch.runners {
+		runners = append(runners, runner)
+	}
+	pool.mtx.Unlock()
+
+	for _, runner := range runners {
+		runner.cancel()
+	}
+}
+
+// removeEntry removes the entry from the queue, preserving the order of the remaining entries.
+func removeEntry(queue []*poolEntry, entry *poolEntry) []*poolEntry {
+	for i, queued := range queue {
+		if queued == entry {
+			return append(queue[:i], queue[i+1:]...)
+		}
+	}
+
+	return queue
+}
@@ -0,0 +1,269 @@
+package health_test
+
+import (
+	"context"
+	"runtime"
+	"strconv"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/jaredpetersen/go-health/health"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMaxConcurrency(t *testing.T) {
+	healthMonitor := health.New(health.WithMaxConcurrency(2))
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	var running int32
+	var maxRunning int32
+	var executions int32
+	checkFunc := func(ctx context.Context) health.Status {
+		current := atomic.AddInt32(&running, 1)
+		for {
+			max := atomic.LoadInt32(&maxRunning)
+			if current <= max || atomic.CompareAndSwapInt32(&maxRunning, max, current) {
+				break
+			}
+		}
+
+		time.Sleep(time.Millisecond * 20)
+		atomic.AddInt32(&running, -1)
+		atomic.AddInt32(&executions, 1)
+
+		return health.Status{State: health.StateUp}
+	}
+
+	for _, name := range []string{"a", "b", "c", "d", "e"} {
+		check := health.NewCheck(name, checkFunc)
+		check.TTL = time.Minute
+		healthMonitor.Monitor(ctx, check)
+	}
+
+	time.Sleep(time.Millisecond * 150)
+
+	assert.Equal(t, int32(2), atomic.LoadInt32(&maxRunning))
+	assert.Equal(t, int32(5), atomic.LoadInt32(&executions))
+	assert.Equal(t, health.StateUp, healthMonitor.Check().State)
+}
+
+func TestMaxConcurrencyPrioritizesCriticalChecks(t *testing.T) {
+	healthMonitor := health.New(health.WithMaxConcurrency(1))
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	var orderMtx sync.Mutex
+	var order []string
+	newCheck := func(name string) health.Check {
+		return health.NewCheck(name, func(ctx context.Context) health.Status {
+			orderMtx.Lock()
+			order = append(order, name)
+			orderMtx.Unlock()
+			return health.Status{State: health.StateUp}
+		})
+	}
+
+	unblock := make(chan struct{})
+	blockingCheck := health.NewCheck("blocking", func(ctx context.Context) health.Status {
+		<-unblock
+		return health.Status{State: health.StateUp}
+	})
+	blockingCheck.TTL = time.Minute
+	healthMonitor.Monitor(ctx, blockingCheck)
+
+	// Wait for the blocking check to hold the only worker
+	time.Sleep(time.Millisecond * 20)
+
+	nonCriticalCheck := newCheck("noncritical")
+	nonCriticalCheck.NonCritical = true
+	nonCriticalCheck.TTL = time.Minute
+	healthMonitor.Monitor(ctx, nonCriticalCheck)
+
+	// Wait for the non-critical check to be queued first
+	time.Sleep(time.Millisecond * 20)
+
+	criticalCheck := newCheck("critical")
+	criticalCheck.TTL = time.Minute
+	healthMonitor.Monitor(ctx, criticalCheck)
+
+	time.Sleep(time.Millisecond * 20)
+
+	stats := healthMonitor.PoolStats()
+	assert.Equal(t, 1, stats.MaxConcurrency)
+	assert.Equal(t, 1, stats.Running)
+	assert.Equal(t, 2, stats.Queued)
+
+	close(unblock)
+
+	time.Sleep(time.Millisecond * 20)
+
+	orderMtx.Lock()
+	assert.Equal(t, []string{"critical", "noncritical"}, order)
+	orderMtx.Unlock()
+
+	stats = healthMonitor.PoolStats()
+	assert.Equal(t, 0, stats.Running)
+	assert.Equal(t, 0, stats.Queued)
+	assert.Equal(t, uint64(3), stats.Acquired)
+	assert.True(t, stats.WaitTime >= time.Millisecond*40, "Wait time is too short")
+}
+
+func TestMaxConcurrencyStopsWaitingOnCancel(t *testing.T) {
+	healthMonitor := health.New(health.WithMaxConcurrency(1))
+	ctx := context.Background()
+
+	unblock := make(chan struct{})
+	defer close(unblock)
+	blockingCheck := health.NewCheck("blocking", func(ctx context.Context) health.Status {
+		select {
+		case <-ctx.Done():
+		case <-unblock:
+		}
+		return health.Status{State: health.StateUp}
+	})
+	healthMonitor.Monitor(ctx, blockingCheck)
+
+	time.Sleep(time.Millisecond * 20)
+
+	queuedCheck := health.NewCheck("queued", func(ctx context.Context) health.Status {
+		return health.Status{State: health.StateUp}
+	})
+	healthMonitor.Monitor(ctx, queuedCheck)
+
+	time.Sleep(time.Millisecond * 20)
+
+	assert.NoError(t, healthMonitor.Unregister(queuedCheck.Name))
+
+	time.Sleep(time.Millisecond * 20)
+
+	stats := healthMonitor.PoolStats()
+	assert.Equal(t, 1, stats.Running)
+	assert.Equal(t, 0, stats.Queued)
+
+	stopCtx, stopCancel := context.WithTimeout(ctx, time.Millisecond*100)
+	defer stopCancel()
+	assert.NoError(t, healthMonitor.Stop(stopCtx))
+
+	stats = healthMonitor.PoolStats()
+	assert.Equal(t, 0, stats.Running)
+}
+
+func TestMaxConcurrencyHoldsWorkerForAbandonedExecution(t *testing.T) {
+	healthMonitor := health.New(health.WithMaxConcurrency(1))
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	release := make(chan struct{})
+	stuckCheck := health.NewCheck("stuck", func(ctx context.Context) health.Status {
+		// Ignore the context entirely
+		<-release
+		return health.Status{State: health.StateUp}
+	})
+	stuckCheck.Timeout = time.Millisecond * 10
+	stuckCheck.EnforceTimeout = true
+	stuckCheck.AbandonLimit = time.Millisecond * 10
+	healthMonitor.Monitor(ctx, stuckCheck)
+
+	time.Sleep(time.Millisecond * 20)
+
+	var atomicCheckCounter int32
+	queuedCheck := health.NewCheck("queued", func(ctx context.Context) health.Status {
+		atomic.AddInt32(&atomicCheckCounter, 1)
+		return health.Status{State: health.StateUp}
+	})
+	healthMonitor.Monitor(ctx, queuedCheck)
+
+	time.Sleep(time.Millisecond * 50)
+
+	// The abandoned execution is still running, so the worker is not available
+	assert.Equal(t, int32(0), atomic.LoadInt32(&atomicCheckCounter), "Check executed while the worker was held")
+	assert.Equal(t, health.StateDown, healthMonitor.Check().CheckStatuses[stuckCheck.Name].Status.State)
+	stats := healthMonitor.PoolStats()
+	assert.Equal(t, 1, stats.Running)
+	assert.Equal(t, 1, stats.Queued)
+
+	close(release)
+
+	time.Sleep(time.Millisecond * 50)
+
+	assert.Equal(t, int32(1), atomic.LoadInt32(&atomicCheckCounter), "Check did not execute once the worker was released")
+}
+
+func TestMaxConcurrencySharesGoroutines(t *testing.T) {
+	healthMonitor := health.New(health.WithMaxConcurrency(4))
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	var executions int32
+	checkFunc := func(ctx context.Context) health.Status {
+		atomic.AddInt32(&executions, 1)
+		return health.Status{State: health.StateUp}
+	}
+
+	goroutines := runtime.NumGoroutine()
+
+	for i := 0; i < 100; i++ {
+		check := health.NewCheck(strconv.Itoa(i), checkFunc)
+		check.TTL = time.Minute
+		healthMonitor.Monitor(ctx, check)
+	}
+
+	time.Sleep(time.Millisecond * 50)
+
+	assert.Equal(t, int32(100), atomic.LoadInt32(&executions))
+	assert.Equal(t, health.StateUp, healthMonitor.Check().State)
+	// The workers, the timer queue, and the context are not multiplied by the number of checks
+	assert.Less(t, runtime.NumGoroutine()-goroutines, 20)
+
+	cancel()
+
+	waited := make(chan struct{})
+	go func() {
+		healthMonitor.Wait()
+		close(waited)
+	}()
+
+	select {
+	case <-waited:
+	case <-time.After(time.Millisecond * 100):
+		assert.Fail(t, "Checks were not cancelled with the context")
+	}
+}
+
+func TestMaxConcurrencyRefresh(t *testing.T) {
+	healthMonitor := health.New(health.WithMaxConcurrency(1), health.WithMinRefreshInterval(0))
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	var executions int32
+	checkFunc := func(ctx context.Context) health.Status {
+		atomic.AddInt32(&executions, 1)
+		return health.Status{State: health.StateUp}
+	}
+	check := health.NewCheck("check", checkFunc)
+	check.TTL = time.Millisecond * 100
+	healthMonitor.Monitor(ctx, check)
+
+	// Wait for the initial execution
+	time.Sleep(time.Millisecond * 50)
+	nextExecution := healthMonitor.Check().CheckStatuses[check.Name].NextExecution
+
+	status, err := healthMonitor.Refresh(ctx, check.Name)
+	assert.NoError(t, err)
+	assert.Equal(t, nextExecution, status.CheckStatuses[check.Name].NextExecution)
+	assert.Equal(t, int32(2), atomic.LoadInt32(&executions))
+
+	// Wait for the scheduled execution that was pending during the refresh
+	time.Sleep(time.Millisecond * 80)
+
+	assert.Equal(t, int32(3), atomic.LoadInt32(&executions))
+}
+
+func TestPoolStatsUnlimitedConcurrency(t *testing.T) {
+	healthMonitor := health.New()
+
+	assert.Equal(t, health.PoolStats{}, healthMonitor.PoolStats())
+}
@@ -0,0 +1,47 @@
+package health
+
+import "context"
+
+// WaitReady blocks until every registered check has executed at least once, so that the cached statuses reflect the
+// actual health of the resources rather than the initial states of the checks. Checks registered while waiting must
+// also execute before WaitReady returns. The context error is returned if the context is done first.
+func (mtr *Monitor) WaitReady(ctx context.Context) error {
+	mtr.mtx.RLock()
+	ready := mtr.ready
+	mtr.mtx.RUnlock()
+
+	select {
+	case <-ready:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// updateReadiness closes the ready channel if every check has executed or replaces it if a check has not executed
+// after it has been closed. The monitor mutex must be held by the caller.
+func (mtr *Monitor) updateReadiness() {
+	pending := hasPendingCheck(mtr.checkStatuses)
+
+	select {
+	case <-mtr.ready:
+		if pending {
+			mtr.ready = make(chan struct{})
+		}
+	default:
+		if !pending {
+			close(mtr.ready)
+		}
+	}
+}
+
+// hasPendingCheck determines if any of the checks have not executed yet.
+func hasPendingCheck(checkStatuses map[string]CheckStatus) bool {
+	for _, checkStatus := range checkStatuses {
+		if checkStatus.Pending {
+			return true
+		}
+	}
+
+	return false
+}
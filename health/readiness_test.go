@@ -0,0 +1,148 @@
+package health_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/jaredpetersen/go-health/health"
+	"github.com/stretchr/testify/assert"
+)
+
+// newBlockingCheck creates a check whose function does not return until the unblock channel is closed.
+func newBlockingCheck(name string, unblock <-chan struct{}) health.Check {
+	checkFunc := func(ctx context.Context) health.Status {
+		<-unblock
+		return health.Status{State: health.StateUp}
+	}
+	check := health.NewCheck(name, checkFunc)
+	check.TTL = time.Minute
+
+	return check
+}
+
+func TestCheckPending(t *testing.T) {
+	healthMonitor := health.New()
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	unblock := make(chan struct{})
+	check := newBlockingCheck("check", unblock)
+	healthMonitor.Monitor(ctx, check)
+
+	checkStatus := healthMonitor.Check().CheckStatuses[check.Name]
+	assert.True(t, checkStatus.Pending)
+	assert.Equal(t, health.StateDown, checkStatus.Status.State)
+	assert.True(t, checkStatus.Timestamp.IsZero(), "Timestamp is set before the first execution")
+
+	close(unblock)
+
+	// Wait for the result to be processed
+	time.Sleep(time.Millisecond * 20)
+
+	checkStatus = healthMonitor.Check().CheckStatuses[check.Name]
+	assert.False(t, checkStatus.Pending)
+	assert.Equal(t, health.StateUp, checkStatus.Status.State)
+}
+
+func TestCheckInitialState(t *testing.T) {
+	healthMonitor := health.New()
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	unblock := make(chan struct{})
+	defer close(unblock)
+	check := newBlockingCheck("check", unblock)
+	check.InitialState = health.StateWarn
+	healthMonitor.Monitor(ctx, check)
+
+	monitorStatus := healthMonitor.Check()
+	assert.Equal(t, health.StateWarn, monitorStatus.State)
+	assert.True(t, monitorStatus.CheckStatuses[check.Name].Pending)
+}
+
+func TestCheckGroupLatchingWaitsForPendingChecks(t *testing.T) {
+	healthMonitor := health.New()
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	unblock := make(chan struct{})
+	check := newBlockingCheck("check", unblock)
+	check.InitialState = health.StateUp
+	check.Groups = []string{health.GroupStartup}
+	healthMonitor.Monitor(ctx, check)
+
+	groupStatus := healthMonitor.CheckGroup(health.GroupStartup)
+	assert.True(t, groupStatus.CheckStatuses[check.Name].Pending)
+
+	close(unblock)
+
+	// Wait for the result to be processed
+	time.Sleep(time.Millisecond * 20)
+
+	groupStatus = healthMonitor.CheckGroup(health.GroupStartup)
+	assert.Equal(t, health.StateUp, groupStatus.State)
+	assert.False(t, groupStatus.CheckStatuses[check.Name].Pending)
+}
+
+func TestWaitReady(t *testing.T) {
+	healthMonitor := health.New()
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	unblock := make(chan struct{})
+	healthMonitor.Monitor(ctx, newBlockingCheck("a", unblock), newBlockingCheck("b", unblock))
+
+	waitCtx, waitCancel := context.WithTimeout(ctx, time.Millisecond*20)
+	defer waitCancel()
+	assert.ErrorIs(t, healthMonitor.WaitReady(waitCtx), context.DeadlineExceeded)
+
+	close(unblock)
+
+	waitCtx, waitCancel = context.WithTimeout(ctx, time.Millisecond*100)
+	defer waitCancel()
+	assert.NoError(t, healthMonitor.WaitReady(waitCtx))
+
+	for _, checkStatus := range healthMonitor.Check().CheckStatuses {
+		assert.False(t, checkStatus.Pending)
+	}
+}
+
+func TestWaitReadyWithoutChecks(t *testing.T) {
+	healthMonitor := health.New()
+	ctx, cancel := context.WithTimeout(context.Background(), time.Millisecond*20)
+	defer cancel()
+
+	assert.NoError(t, healthMonitor.WaitReady(ctx))
+}
+
+func TestWaitReadyAfterRegister(t *testing.T) {
+	healthMonitor := health.New()
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	unblock := make(chan struct{})
+	defer close(unblock)
+	assert.NoError(t, healthMonitor.Register(ctx, newBlockingCheck("check", unblock)))
+
+	waitCtx, waitCancel := context.WithTimeout(ctx, time.Millisecond*20)
+	defer waitCancel()
+	assert.ErrorIs(t, healthMonitor.WaitReady(waitCtx), context.DeadlineExceeded)
+}
+
+func TestWaitReadyAfterUnregister(t *testing.T) {
+	healthMonitor := health.New()
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	unblock := make(chan struct{})
+	defer close(unblock)
+	check := newBlockingCheck("check", unblock)
+	healthMonitor.Monitor(ctx, check)
+
+	assert.NoError(t, healthMonitor.Unregister(check.Name))
+
+	waitCtx, waitCancel := context.WithTimeout(ctx, time.Millisecond*20)
+	defer waitCancel()
+	assert.NoError(t, healthMonitor.WaitReady(waitCtx))
+}
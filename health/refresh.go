@@ -0,0 +1,131 @@
+package health
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+// defaultMinRefreshInterval is the min time since the last execution of a check before Refresh executes it again unless
+// configured otherwise.
+const defaultMinRefreshInterval = time.Second
+
+// WithMinRefreshInterval configures the min time since the last execution of a check before Refresh executes it
+// again. Checks that executed more recently than the interval are served from the cache instead. Defaults to one
+// second, which limits every check to roughly one execution per second on top of its schedule no matter how often
+// Refresh is called. An interval less than or equal to zero disables the limit, meaning that every Refresh executes
+// the checks unless a refresh of the same check is already in progress.
+func WithMinRefreshInterval(interval time.Duration) Option {
+	return func(mtr *Monitor) {
+		mtr.minRefreshInterval = interval
+	}
+}
+
+// refreshCall is a requested execution of a check that every concurrent caller of Refresh waits on together.
+type refreshCall struct {
+	// done is closed once the execution has completed and the result has been cached.
+	done chan struct{}
+}
+
+// Refresh executes the checks with the provided names immediately, or every registered check if no names are provided,
+// waits for the executions to complete, and returns the resulting health status. This is useful when a fresh answer is
+// needed rather than the cached one, e.g. when gating a deployment.
+//
+// Concurrent refreshes of the same check are coalesced into a single execution and checks that executed more recently
+// than the min refresh interval, one second unless configured with WithMinRefreshInterval, are not executed again.
+// Sequential refreshes may therefore still execute a check once per min refresh interval on top of its schedule.
+//
+// The executions happen on the goroutines polling the checks, so they are subject to the same timeouts and concurrency
+// limits as scheduled executions. The schedule of the check is not affected; the upcoming scheduled execution still
+// happens at the time reported by NextExecution.
+//
+// Checks that are no longer polled because the context that they were registered with is done are served from the
+// cache. An error wrapping ErrCheckNotFound is returned if any of the checks are not registered, in which case none of
+// them are executed, and ErrMonitorStopped is returned if Stop has been called. If the context is done before the
+// executions complete, the latest cached status is returned along with the context error.
+func (mtr *Monitor) Refresh(ctx context.Context, names ...string) (MonitorStatus, error) {
+	mtr.mtx.Lock()
+
+	if mtr.stopped {
+		mtr.mtx.Unlock()
+		return MonitorStatus{}, ErrMonitorStopped
+	}
+
+	if len(names) == 0 {
+		for name := range mtr.runners {
+			names = append(names, name)
+		}
+	}
+
+	for _, name := range names {
+		if _, ok := mtr.runners[name]; !ok {
+			mtr.mtx.Unlock()
+			return MonitorStatus{}, fmt.Errorf("%w: %s", ErrCheckNotFound, name)
+		}
+	}
+
+	now := time.Now()
+	var calls []*refreshCall
+	for _, name := range names {
+		runner := mtr.runners[name]
+		if _, ok := mtr.activeRunners[runner]; !ok {
+			// The polling goroutine has exited, so nothing would execute the check
+			continue
+		}
+
+		lastExecution := mtr.checkStatuses[name].Timestamp
+		if call := runner.requestRefresh(now, lastExecution, mtr.minRefreshInterval); call != nil {
+			calls = append(calls, call)
+		}
+	}
+
+	mtr.mtx.Unlock()
+
+	for _, call := range calls {
+		select {
+		case <-call.done:
+		case <-ctx.Done():
+			return mtr.Check(), ctx.Err()
+		}
+	}
+
+	return mtr.Check(), nil
+}
+
+// requestRefresh signals the polling goroutine to execute the check function immediately and returns the refresh to
+// wait on. If a refresh is already in progress, that refresh is returned instead. Nil is returned if the check executed
+// more recently than the min refresh interval. The monitor mutex must be held by the caller.
+func (runner *checkRunner) requestRefresh(now, lastExecution time.Time, minInterval time.Duration) *refreshCall {
+	if runner.pendingRefresh != nil {
+		return runner.pendingRefresh
+	}
+
+	if minInterval > 0 && !lastExecution.IsZero() && now.Sub(lastExecution) < minInterval {
+		return nil
+	}
+
+	runner.pendingRefresh = &refreshCall{done: make(chan struct{})}
+
+	if runner.pool != nil {
+		runner.pool.refresh(runner)
+		return runner.pendingRefresh
+	}
+
+	select {
+	case runner.refresh <- struct{}{}:
+	default:
+	}
+
+	return runner.pendingRefresh
+}
+
+// completeRefresh notifies everyone waiting on the pending refresh, if any. The monitor mutex must be held by the
+// caller.
+func (runner *checkRunner) completeRefresh() {
+	if runner.pendingRefresh == nil {
+		return
+	}
+
+	close(runner.pendingRefresh.done)
+	runner.pendingRefresh = nil
+}
@@ -0,0 +1,245 @@
+package health_test
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/jaredpetersen/go-health/health"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRefresh(t *testing.T) {
+	healthMonitor := health.New(health.WithMinRefreshInterval(0))
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	var executions int32
+	checkFunc := func(ctx context.Context) health.Status {
+		if atomic.AddInt32(&executions, 1) == 1 {
+			return health.Status{State: health.StateDown}
+		}
+		return health.Status{State: health.StateUp}
+	}
+	check := health.NewCheck("check", checkFunc)
+	check.TTL = time.Minute
+	healthMonitor.Monitor(ctx, check)
+
+	// Wait for the initial execution
+	time.Sleep(time.Millisecond * 20)
+	assert.Equal(t, health.StateDown, healthMonitor.Check().State)
+
+	status, err := healthMonitor.Refresh(ctx, check.Name)
+	assert.NoError(t, err)
+	assert.Equal(t, health.StateUp, status.State)
+	assert.Equal(t, health.StateUp, status.CheckStatuses[check.Name].Status.State)
+	assert.Equal(t, int32(2), atomic.LoadInt32(&executions))
+}
+
+func TestRefreshCoalescesConcurrentRequests(t *testing.T) {
+	healthMonitor := health.New(health.WithMinRefreshInterval(0))
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	var executions int32
+	checkFunc := func(ctx context.Context) health.Status {
+		atomic.AddInt32(&executions, 1)
+		time.Sleep(time.Millisecond * 50)
+		return health.Status{State: health.StateUp}
+	}
+	check := health.NewCheck("check", checkFunc)
+	check.TTL = time.Minute
+	healthMonitor.Monitor(ctx, check)
+
+	// Wait for the initial execution
+	time.Sleep(time.Millisecond * 70)
+
+	var wg sync.WaitGroup
+	for i := 0; i < 5; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			status, err := healthMonitor.Refresh(ctx, check.Name)
+			assert.NoError(t, err)
+			assert.Equal(t, health.StateUp, status.State)
+		}()
+	}
+	wg.Wait()
+
+	assert.Equal(t, int32(2), atomic.LoadInt32(&executions))
+}
+
+func TestRefreshAllChecks(t *testing.T) {
+	healthMonitor := health.New(health.WithMinRefreshInterval(0))
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	var executions int32
+	checkFunc := func(ctx context.Context) health.Status {
+		atomic.AddInt32(&executions, 1)
+		return health.Status{State: health.StateUp}
+	}
+	checkA := health.NewCheck("a", checkFunc)
+	checkA.TTL = time.Minute
+	checkB := health.NewCheck("b", checkFunc)
+	checkB.TTL = time.Minute
+	healthMonitor.Monitor(ctx, checkA, checkB)
+
+	// Wait for the initial executions
+	time.Sleep(time.Millisecond * 20)
+
+	status, err := healthMonitor.Refresh(ctx)
+	assert.NoError(t, err)
+	assert.Equal(t, 2, len(status.CheckStatuses))
+	assert.Equal(t, int32(4), atomic.LoadInt32(&executions))
+}
+
+func TestRefreshKeepsSchedule(t *testing.T) {
+	healthMonitor := health.New(health.WithMinRefreshInterval(0))
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	var executions int32
+	checkFunc := func(ctx context.Context) health.Status {
+		atomic.AddInt32(&executions, 1)
+		return health.Status{State: health.StateUp}
+	}
+	check := health.NewCheck("check", checkFunc)
+	check.TTL = time.Millisecond * 100
+	healthMonitor.Monitor(ctx, check)
+
+	// Wait for the initial execution
+	time.Sleep(time.Millisecond * 50)
+	nextExecution := healthMonitor.Check().CheckStatuses[check.Name].NextExecution
+
+	status, err := healthMonitor.Refresh(ctx, check.Name)
+	assert.NoError(t, err)
+	assert.Equal(t, nextExecution, status.CheckStatuses[check.Name].NextExecution)
+	assert.Equal(t, int32(2), atomic.LoadInt32(&executions))
+
+	// Wait for the scheduled execution that was pending during the refresh
+	time.Sleep(time.Millisecond * 80)
+
+	assert.Equal(t, int32(3), atomic.LoadInt32(&executions))
+}
+
+func TestRefreshMinRefreshInterval(t *testing.T) {
+	healthMonitor := health.New(health.WithMinRefreshInterval(time.Minute))
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	var executions int32
+	checkFunc := func(ctx context.Context) health.Status {
+		atomic.AddInt32(&executions, 1)
+		return health.Status{State: health.StateUp}
+	}
+	check := health.NewCheck("check", checkFunc)
+	check.TTL = time.Minute
+	healthMonitor.Monitor(ctx, check)
+
+	// Wait for the initial execution
+	time.Sleep(time.Millisecond * 20)
+
+	status, err := healthMonitor.Refresh(ctx, check.Name)
+	assert.NoError(t, err)
+	assert.Equal(t, health.StateUp, status.State)
+	assert.Equal(t, int32(1), atomic.LoadInt32(&executions))
+}
+
+func TestRefreshDefaultMinRefreshInterval(t *testing.T) {
+	healthMonitor := health.New()
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	var executions int32
+	checkFunc := func(ctx context.Context) health.Status {
+		atomic.AddInt32(&executions, 1)
+		return health.Status{State: health.StateUp}
+	}
+	check := health.NewCheck("check", checkFunc)
+	check.TTL = time.Minute
+	healthMonitor.Monitor(ctx, check)
+
+	// Wait for the initial execution
+	time.Sleep(time.Millisecond * 20)
+
+	_, err := healthMonitor.Refresh(ctx, check.Name)
+	assert.NoError(t, err)
+	assert.Equal(t, int32(1), atomic.LoadInt32(&executions))
+
+	// Wait for the default min refresh interval to pass
+	time.Sleep(time.Second)
+
+	_, err = healthMonitor.Refresh(ctx, check.Name)
+	assert.NoError(t, err)
+	assert.Equal(t, int32(2), atomic.LoadInt32(&executions))
+}
+
+func TestRefreshContextDone(t *testing.T) {
+	healthMonitor := health.New()
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	unblock := make(chan struct{})
+	defer close(unblock)
+	checkFunc := func(ctx context.Context) health.Status {
+		<-unblock
+		return health.Status{State: health.StateUp}
+	}
+	check := health.NewCheck("check", checkFunc)
+	healthMonitor.Monitor(ctx, check)
+
+	refreshCtx, refreshCancel := context.WithTimeout(ctx, time.Millisecond*20)
+	defer refreshCancel()
+
+	status, err := healthMonitor.Refresh(refreshCtx, check.Name)
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+	assert.Equal(t, health.StateDown, status.State)
+}
+
+func TestRefreshCancelledCheck(t *testing.T) {
+	healthMonitor := health.New()
+	ctx, cancel := context.WithCancel(context.Background())
+
+	var atomicCheckCounter int32
+	checkFunc := func(ctx context.Context) health.Status {
+		atomic.AddInt32(&atomicCheckCounter, 1)
+		return health.Status{State: health.StateUp}
+	}
+	check := health.NewCheck("check", checkFunc)
+	healthMonitor.Monitor(ctx, check)
+
+	// Wait for goroutines to kick in
+	time.Sleep(time.Millisecond * 50)
+
+	cancel()
+	healthMonitor.Wait()
+
+	refreshCtx, refreshCancel := context.WithTimeout(context.Background(), time.Second)
+	defer refreshCancel()
+
+	status, err := healthMonitor.Refresh(refreshCtx, check.Name)
+	assert.NoError(t, err)
+	assert.Equal(t, health.StateUp, status.State)
+	assert.Equal(t, int32(1), atomic.LoadInt32(&atomicCheckCounter))
+}
+
+func TestRefreshCheckNotFound(t *testing.T) {
+	healthMonitor := health.New()
+	ctx := context.Background()
+
+	_, err := healthMonitor.Refresh(ctx, "missing")
+	assert.ErrorIs(t, err, health.ErrCheckNotFound)
+}
+
+func TestRefreshStoppedMonitor(t *testing.T) {
+	healthMonitor := health.New()
+	ctx := context.Background()
+
+	assert.NoError(t, healthMonitor.Stop(ctx))
+
+	_, err := healthMonitor.Refresh(ctx)
+	assert.ErrorIs(t, err, health.ErrMonitorStopped)
+}
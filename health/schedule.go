@@ -0,0 +1,67 @@
+package health
+
+import (
+	"math/rand"
+	"time"
+)
+
+// initialDelay determines the time to wait until the first execution of the check function and schedules it. It is
+// called once before the polling goroutine, or the pool, executes the check for the first time.
+func (runner *checkRunner) initialDelay() time.Duration {
+	var delay time.Duration
+	if runner.check.MaxInitialDelay > 0 {
+		delay = time.Duration(runner.random().Int63n(int64(runner.check.MaxInitialDelay)))
+	}
+
+	runner.scheduled = time.Now().Add(delay)
+	runner.next = runner.scheduled
+
+	return delay
+}
+
+// nextRun determines the time of the next execution of the check function given the interval between executions. The
+// next execution is scheduled relative to the previous scheduled execution rather than when it finished so that the
+// execution time does not cause the cadence to drift. Executions that would have started while the previous one was
+// still running are skipped. Jitter is applied to the returned time but does not affect the schedule of later
+// executions. The monitor mutex must be held by the caller.
+func (runner *checkRunner) nextRun(interval time.Duration, now time.Time) time.Time {
+	if interval <= 0 {
+		runner.scheduled = now
+		runner.next = now
+		return now
+	}
+
+	next := runner.scheduled.Add(interval)
+	if !next.After(now) {
+		missed := now.Sub(next)/interval + 1
+		next = next.Add(missed * interval)
+	}
+	runner.scheduled = next
+	runner.next = next.Add(runner.jitter(interval))
+
+	return runner.next
+}
+
+// jitter returns a random offset of up to the check's jitter fraction of the interval in either direction.
+func (runner *checkRunner) jitter(interval time.Duration) time.Duration {
+	fraction := runner.check.Jitter
+	if fraction <= 0 {
+		return 0
+	}
+	if fraction > 1 {
+		fraction = 1
+	}
+
+	spread := float64(interval) * fraction
+	return time.Duration((runner.random().Float64()*2 - 1) * spread)
+}
+
+// random returns the source of randomness for the runner, creating it if necessary. Each runner is seeded separately
+// so that replicas started at the same time do not share a schedule.
+func (runner *checkRunner) random() *rand.Rand {
+	if runner.rand == nil {
+		runner.rand = rand.New(rand.NewSource(time.Now().UnixNano()))
+	}
+
+	return runner.rand
+}
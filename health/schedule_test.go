@@ -0,0 +1,151 @@
+package health_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/jaredpetersen/go-health/health"
+	"github.com/stretchr/testify/assert"
+)
+
+// executionStarts returns the time between the starts of each of the executions in the check's history.
+func executionStarts(t *testing.T, healthMonitor *health.Monitor, name string) []time.Duration {
+	history, err := healthMonitor.History(name)
+	assert.NoError(t, err)
+
+	var starts []time.Duration
+	for i := 1; i < len(history); i++ {
+		starts = append(starts, history[i].Start.Sub(history[i-1].Start))
+	}
+
+	return starts
+}
+
+func TestCheckFixedCadence(t *testing.T) {
+	healthMonitor := health.New()
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	checkFunc := func(ctx context.Context) health.Status {
+		time.Sleep(time.Millisecond * 20)
+		return health.Status{State: health.StateUp}
+	}
+	check := health.NewCheck("check", checkFunc)
+	check.TTL = time.Millisecond * 50
+	check.HistorySize = 10
+	healthMonitor.Monitor(ctx, check)
+
+	time.Sleep(time.Millisecond * 180)
+
+	starts := executionStarts(t, healthMonitor, check.Name)
+	assert.Equal(t, 3, len(starts))
+	for _, start := range starts {
+		// Execution time is not added to the TTL
+		assert.InDelta(t, float64(time.Millisecond*50), float64(start), float64(time.Millisecond*10))
+	}
+}
+
+func TestCheckSkipsOverrunningExecutions(t *testing.T) {
+	healthMonitor := health.New()
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	checkFunc := func(ctx context.Context) health.Status {
+		time.Sleep(time.Millisecond * 50)
+		return health.Status{State: health.StateUp}
+	}
+	check := health.NewCheck("check", checkFunc)
+	check.TTL = time.Millisecond * 20
+	check.HistorySize = 10
+	healthMonitor.Monitor(ctx, check)
+
+	time.Sleep(time.Millisecond * 200)
+
+	starts := executionStarts(t, healthMonitor, check.Name)
+	assert.NotEmpty(t, starts)
+	for _, start := range starts {
+		// Executions at 20ms and 40ms are skipped, the next one starts at 60ms
+		assert.InDelta(t, float64(time.Millisecond*60), float64(start), float64(time.Millisecond*10))
+	}
+}
+
+func TestCheckMaxInitialDelay(t *testing.T) {
+	healthMonitor := health.New()
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	checkFunc := func(ctx context.Context) health.Status {
+		return health.Status{State: health.StateUp}
+	}
+	check := health.NewCheck("check", checkFunc)
+	check.MaxInitialDelay = time.Millisecond * 50
+	check.TTL = time.Minute
+	check.HistorySize = 1
+
+	before := time.Now()
+	healthMonitor.Monitor(ctx, check)
+
+	time.Sleep(time.Millisecond * 100)
+
+	history, err := healthMonitor.History(check.Name)
+	assert.NoError(t, err)
+	if assert.Equal(t, 1, len(history)) {
+		delay := history[0].Start.Sub(before)
+		assert.True(t, delay < time.Millisecond*60, "Initial delay is too long")
+	}
+}
+
+func TestCheckJitter(t *testing.T) {
+	healthMonitor := health.New()
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	sub := healthMonitor.Subscribe(health.WithExecutionEvents())
+	defer sub.Unsubscribe()
+
+	checkFunc := func(ctx context.Context) health.Status {
+		return health.Status{State: health.StateUp}
+	}
+	check := health.NewCheck("check", checkFunc)
+	check.TTL = time.Millisecond * 20
+	check.Jitter = 0.5
+	healthMonitor.Monitor(ctx, check)
+
+	var start time.Time
+	distinct := make(map[time.Duration]struct{})
+	for i := 0; i < 5; {
+		event := receiveEvent(t, sub)
+		if event.Type != health.EventCheckExecuted {
+			continue
+		}
+		if start.IsZero() {
+			start = event.CheckStatus.Timestamp
+		}
+		i++
+
+		// Jitter moves each execution off of the TTL cadence by up to 10ms without drifting from it
+		scheduled := start.Add(check.TTL * time.Duration(i))
+		offset := event.CheckStatus.NextExecution.Sub(scheduled)
+		assert.InDelta(t, 0, float64(offset), float64(time.Millisecond*12))
+		distinct[offset.Round(time.Millisecond*2)] = struct{}{}
+	}
+	assert.True(t, len(distinct) > 1, "Executions are not jittered")
+}
+
+func TestCheckScheduleStopsWaitingOnCancel(t *testing.T) {
+	healthMonitor := health.New()
+	ctx := context.Background()
+
+	checkFunc := func(ctx context.Context) health.Status {
+		return health.Status{State: health.StateUp}
+	}
+	check := health.NewCheck("check", checkFunc)
+	check.MaxInitialDelay = time.Hour
+	healthMonitor.Monitor(ctx, check)
+
+	stopCtx, stopCancel := context.WithTimeout(ctx, time.Millisecond*100)
+	defer stopCancel()
+
+	assert.NoError(t, healthMonitor.Stop(stopCtx))
+}
@@ -0,0 +1,92 @@
+package health
+
+import "time"
+
+// isStale determines if the cached status of the check is older than the max age of the check as of the provided time.
+// The age of a check that has not executed yet is measured from the time that it was registered.
+func (check Check) isStale(checkStatus CheckStatus, registered, now time.Time) bool {
+	staleAt, ok := check.staleAt(checkStatus, registered)
+	return ok && now.After(staleAt)
+}
+
+// staleAt determines the time after which the cached status of the check is stale. False is returned if the check does
+// not detect staleness.
+func (check Check) staleAt(checkStatus CheckStatus, registered time.Time) (time.Time, bool) {
+	if check.MaxAge <= 0 {
+		return time.Time{}, false
+	}
+
+	maxAge := check.MaxAge
+
+	if checkStatus.Pending {
+		if initial := check.MaxInitialDelay + check.Timeout; initial > maxAge {
+			maxAge = initial
+		}
+
+		return registered.Add(maxAge), true
+	}
+
+	if scheduled := checkStatus.NextExecution.Sub(checkStatus.Timestamp) + check.Timeout; scheduled > maxAge {
+		maxAge = scheduled
+	}
+
+	return checkStatus.Timestamp.Add(maxAge), true
+}
+
+// degradeStale returns the provided status of the runner's check degraded to the stale state if it is stale as of the
+// provided time.
+func (runner *checkRunner) degradeStale(checkStatus CheckStatus, now time.Time) CheckStatus {
+	if runner.check.isStale(checkStatus, runner.registered, now) {
+		checkStatus.Stale = true
+		checkStatus.Status.State = compareState(checkStatus.Status.State, runner.check.StaleState)
+	}
+
+	return checkStatus
+}
+
+// watchStaleness starts a timer that notifies the subscribers once the cached status of the runner's check goes stale,
+// replacing the timer for the previous status. The monitor mutex must be held by the caller.
+func (mtr *Monitor) watchStaleness(runner *checkRunner) {
+	runner.stopStaleTimer()
+
+	checkStatus := mtr.checkStatuses[runner.check.Name]
+	staleAt, ok := runner.check.staleAt(checkStatus, runner.registered)
+	if !ok {
+		return
+	}
+
+	runner.staleTimer = time.AfterFunc(time.Until(staleAt), func() {
+		mtr.publishStale(runner, checkStatus.Timestamp)
+	})
+}
+
+// publishStale notifies the subscribers that the cached status of the runner's check has gone stale. Nothing is
+// published if the status has been updated or the check is no longer registered since the timer was started.
+func (mtr *Monitor) publishStale(runner *checkRunner, timestamp time.Time) {
+	mtr.mtx.Lock()
+	defer mtr.mtx.Unlock()
+
+	checkStatus := mtr.checkStatuses[runner.check.Name]
+	if mtr.runners[runner.check.Name] != runner || !checkStatus.Timestamp.Equal(timestamp) {
+		return
+	}
+
+	now := time.Now()
+	if !runner.check.isStale(checkStatus, runner.registered, now) {
+		// The timer fired right at the max age, so try again once the status is older than that
+		mtr.watchStaleness(runner)
+		return
+	}
+
+	mtr.publishCheckStatus(runner.check.Name, checkStatus, runner.degradeStale(checkStatus, now), false)
+	mtr.publishMonitorState()
+}
+
+// stopStaleTimer stops the timer watching the staleness of the cached status, if any. The monitor mutex must be held by
+// the caller.
+func (runner *checkRunner) stopStaleTimer() {
+	if runner.staleTimer != nil {
+		runner.staleTimer.Stop()
+		runner.staleTimer = nil
+	}
+}
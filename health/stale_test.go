@@ -0,0 +1,169 @@
+package health_test
+
+import (
+	"context"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/jaredpetersen/go-health/health"
+	"github.com/stretchr/testify/assert"
+)
+
+// newStuckCheck creates a check whose function succeeds once and then blocks until the context is done.
+func newStuckCheck(name string) health.Check {
+	var executions int32
+	checkFunc := func(ctx context.Context) health.Status {
+		if atomic.AddInt32(&executions, 1) > 1 {
+			<-ctx.Done()
+		}
+		return health.Status{State: health.StateUp}
+	}
+	check := health.NewCheck(name, checkFunc)
+	check.TTL = time.Millisecond * 10
+
+	return check
+}
+
+func TestCheckStale(t *testing.T) {
+	healthMonitor := health.New()
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	check := newStuckCheck("check")
+	check.MaxAge = time.Millisecond * 40
+	healthMonitor.Monitor(ctx, check)
+
+	// Wait for the first execution
+	time.Sleep(time.Millisecond * 20)
+
+	monitorStatus := healthMonitor.Check()
+	assert.Equal(t, health.StateUp, monitorStatus.State)
+	assert.False(t, monitorStatus.CheckStatuses[check.Name].Stale)
+
+	// Wait for the status to go stale
+	time.Sleep(time.Millisecond * 50)
+
+	monitorStatus = healthMonitor.Check()
+	assert.Equal(t, health.StateDown, monitorStatus.State)
+	assert.True(t, monitorStatus.CheckStatuses[check.Name].Stale)
+	assert.Equal(t, health.StateDown, monitorStatus.CheckStatuses[check.Name].Status.State)
+	assert.Equal(t, health.StateUp, monitorStatus.CheckStatuses[check.Name].LastResult.State)
+}
+
+func TestCheckStaleState(t *testing.T) {
+	healthMonitor := health.New()
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	check := newStuckCheck("check")
+	check.MaxAge = time.Millisecond * 20
+	check.StaleState = health.StateWarn
+	check.Groups = []string{health.GroupReadiness}
+	healthMonitor.Monitor(ctx, check)
+
+	// Wait for the status to go stale
+	time.Sleep(time.Millisecond * 60)
+
+	monitorStatus := healthMonitor.Check()
+	assert.Equal(t, health.StateWarn, monitorStatus.State)
+	assert.True(t, monitorStatus.CheckStatuses[check.Name].Stale)
+
+	groupStatus := healthMonitor.CheckGroup(health.GroupReadiness)
+	assert.Equal(t, health.StateWarn, groupStatus.State)
+	assert.True(t, groupStatus.CheckStatuses[check.Name].Stale)
+}
+
+func TestCheckStaleWaitsForScheduledExecution(t *testing.T) {
+	healthMonitor := health.New()
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	checkFunc := func(ctx context.Context) health.Status {
+		return health.Status{State: health.StateUp}
+	}
+	check := health.NewCheck("check", checkFunc)
+	check.TTL = time.Millisecond * 200
+	check.MaxAge = time.Millisecond * 10
+	healthMonitor.Monitor(ctx, check)
+
+	time.Sleep(time.Millisecond * 50)
+
+	monitorStatus := healthMonitor.Check()
+	assert.Equal(t, health.StateUp, monitorStatus.State)
+	assert.False(t, monitorStatus.CheckStatuses[check.Name].Stale)
+}
+
+func TestCheckStaleDisabled(t *testing.T) {
+	healthMonitor := health.New()
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	check := newStuckCheck("check")
+	healthMonitor.Monitor(ctx, check)
+
+	time.Sleep(time.Millisecond * 60)
+
+	monitorStatus := healthMonitor.Check()
+	assert.Equal(t, health.StateUp, monitorStatus.State)
+	assert.False(t, monitorStatus.CheckStatuses[check.Name].Stale)
+}
+
+func TestCheckStalePending(t *testing.T) {
+	healthMonitor := health.New()
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	unblock := make(chan struct{})
+	defer close(unblock)
+	checkFunc := func(ctx context.Context) health.Status {
+		<-unblock
+		return health.Status{State: health.StateUp}
+	}
+	check := health.NewCheck("check", checkFunc)
+	check.InitialState = health.StateUp
+	check.MaxAge = time.Millisecond * 40
+	healthMonitor.Monitor(ctx, check)
+
+	time.Sleep(time.Millisecond * 20)
+
+	monitorStatus := healthMonitor.Check()
+	assert.Equal(t, health.StateUp, monitorStatus.State)
+	assert.False(t, monitorStatus.CheckStatuses[check.Name].Stale)
+
+	// Wait for the check to go stale without ever having executed
+	time.Sleep(time.Millisecond * 40)
+
+	monitorStatus = healthMonitor.Check()
+	assert.Equal(t, health.StateDown, monitorStatus.State)
+	assert.True(t, monitorStatus.CheckStatuses[check.Name].Pending)
+	assert.True(t, monitorStatus.CheckStatuses[check.Name].Stale)
+}
+
+func TestCheckStalePublishesEvents(t *testing.T) {
+	healthMonitor := health.New()
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	check := newStuckCheck("check")
+	check.MaxAge = time.Millisecond * 40
+	healthMonitor.Monitor(ctx, check)
+
+	// Wait for the first execution
+	time.Sleep(time.Millisecond * 20)
+
+	sub := healthMonitor.Subscribe()
+	defer sub.Unsubscribe()
+
+	event := receiveEvent(t, sub)
+	assert.Equal(t, health.EventCheckStateChanged, event.Type)
+	assert.Equal(t, check.Name, event.Check)
+	assert.Equal(t, health.StateUp, event.PreviousState)
+	assert.Equal(t, health.StateDown, event.State)
+	assert.True(t, event.CheckStatus.Stale)
+
+	event = receiveEvent(t, sub)
+	assert.Equal(t, health.EventMonitorStateChanged, event.Type)
+	assert.Equal(t, health.StateUp, event.PreviousState)
+	assert.Equal(t, health.StateDown, event.State)
+}
@@ -0,0 +1,237 @@
+package health
+
+import (
+	"sync/atomic"
+	"time"
+)
+
+// defaultSubscriptionBufferSize is the number of events that a subscription buffers by default before events are
+// dropped.
+const defaultSubscriptionBufferSize = 64
+
+// EventType indicates what happened to cause an event to be published.
+type EventType int
+
+const (
+	// EventCheckStateChanged indicates that the state of an individual check changed.
+	EventCheckStateChanged EventType = iota
+	// EventMonitorStateChanged indicates that the overall state of the monitor changed.
+	EventMonitorStateChanged
+	// EventCheckExecuted indicates that a check function was executed. These events are only published to
+	// subscriptions that opted in via WithExecutionEvents.
+	EventCheckExecuted
+)
+
+// Event describes a change in health that subscribers are notified of.
+type Event struct {
+	// Type indicates what happened.
+	Type EventType
+	// Check is the name of the check the event relates to. Empty for EventMonitorStateChanged.
+	Check string
+	// PreviousState is the state of the check or monitor before the event.
+	PreviousState State
+	// State is the state of the check or monitor after the event.
+	State State
+	// CheckStatus is the status of the check after the event. Left at its zero-value for EventMonitorStateChanged.
+	CheckStatus CheckStatus
+	// Timestamp is the time the event occurred.
+	Timestamp time.Time
+}
+
+// SubscribeOption is used to configure optional subscription behavior.
+type SubscribeOption func(sub *Subscription)
+
+// WithSubscriptionBuffer configures the number of events that the subscription buffers. Events are dropped rather
+// than delivered late when the buffer is full. Values less than one are ignored.
+func WithSubscriptionBuffer(size int) SubscribeOption {
+	return func(sub *Subscription) {
+		if size > 0 {
+			sub.bufferSize = size
+		}
+	}
+}
+
+// WithExecutionEvents configures the subscription to also receive an EventCheckExecuted event every time a check
+// function is executed, not just when the state changes.
+func WithExecutionEvents() SubscribeOption {
+	return func(sub *Subscription) {
+		sub.executions = true
+	}
+}
+
+// Subscription receives events from a monitor.
+//
+// Events are delivered without ever blocking the goroutines polling the checks. If the subscriber is not keeping up
+// and the subscription's buffer is full, new events are dropped and counted instead.
+type Subscription struct {
+	// C is the channel on which the events are delivered. It is closed when the subscription is cancelled. Nil for
+	// subscriptions created with SubscribeFunc.
+	C <-chan Event
+	// events is the sending side of the event channel.
+	events chan Event
+	// bufferSize is the capacity of the event channel.
+	bufferSize int
+	// executions indicates that EventCheckExecuted events should be delivered.
+	executions bool
+	// dropped is the number of events that could not be delivered. Must be accessed atomically.
+	dropped uint64
+	// mtr is the monitor publishing events to the subscription.
+	mtr *Monitor
+}
+
+// Subscribe creates a subscription that delivers events on its channel whenever the state of a check or the overall
+// state of the monitor changes. The subscription must be cancelled with Unsubscribe once it is no longer needed.
+func (mtr *Monitor) Subscribe(opts ...SubscribeOption) *Subscription {
+	sub := mtr.newSubscription(opts)
+	sub.C = sub.events
+
+	mtr.addSubscription(sub)
+
+	return sub
+}
+
+// SubscribeFunc creates a subscription that calls the provided function whenever the state of a check or the overall
+// state of the monitor changes. The function is called sequentially from a dedicated goroutine so a slow function
+// will not block the checks, though events are dropped once the subscription's buffer is full. The subscription must
+// be cancelled with Unsubscribe once it is no longer needed.
+func (mtr *Monitor) SubscribeFunc(fn func(event Event), opts ...SubscribeOption) *Subscription {
+	sub := mtr.newSubscription(opts)
+
+	go func() {
+		for event := range sub.events {
+			fn(event)
+		}
+	}()
+
+	mtr.addSubscription(sub)
+
+	return sub
+}
+
+// newSubscription creates a subscription configured with the provided options.
+func (mtr *Monitor) newSubscription(opts []SubscribeOption) *Subscription {
+	sub := &Subscription{
+		bufferSize: defaultSubscriptionBufferSize,
+		mtr:        mtr,
+	}
+
+	for _, opt := range opts {
+		opt(sub)
+	}
+
+	sub.events = make(chan Event, sub.bufferSize)
+
+	return sub
+}
+
+// addSubscription registers the subscription with the monitor so that it starts receiving events.
+func (mtr *Monitor) addSubscription(sub *Subscription) {
+	mtr.mtx.Lock()
+	defer mtr.mtx.Unlock()
+
+	// Only changes that happen from here on out should be published to the subscribers
+	if len(mtr.subscriptions) == 0 {
+		mtr.publishedState = mtr.currentState()
+	}
+
+	mtr.subscriptions[sub] = struct{}{}
+}
+
+// Unsubscribe cancels the subscription and closes its channel. It is safe to call Unsubscribe more than once.
+func (sub *Subscription) Unsubscribe() {
+	sub.mtr.mtx.Lock()
+	defer sub.mtr.mtx.Unlock()
+
+	if _, ok := sub.mtr.subscriptions[sub]; !ok {
+		return
+	}
+
+	delete(sub.mtr.subscriptions, sub)
+	close(sub.events)
+}
+
+// Dropped returns the number of events that were dropped because the subscription's buffer was full.
+func (sub *Subscription) Dropped() uint64 {
+	return atomic.LoadUint64(&sub.dropped)
+}
+
+// deliver sends the event to the subscriber without blocking, dropping it if the buffer is full.
+func (sub *Subscription) deliver(event Event) {
+	select {
+	case sub.events <- event:
+	default:
+		atomic.AddUint64(&sub.dropped, 1)
+	}
+}
+
+// publishCheckStatus notifies the subscribers of a change to a check status. The monitor mutex must be held by the
+// caller so that events are delivered in order.
+func (mtr *Monitor) publishCheckStatus(checkName string, previous CheckStatus, next CheckStatus, executed bool) {
+	if len(mtr.subscriptions) == 0 {
+		return
+	}
+
+	event := Event{
+		Check:         checkName,
+		PreviousState: previous.Status.State,
+		State:         next.Status.State,
+		CheckStatus:   next,
+		Timestamp:     time.Now(),
+	}
+
+	if executed {
+		event.Type = EventCheckExecuted
+		for sub := range mtr.subscriptions {
+			if sub.executions {
+				sub.deliver(event)
+			}
+		}
+	}
+
+	if previous.Status.State != next.Status.State {
+		event.Type = EventCheckStateChanged
+		for sub := range mtr.subscriptions {
+			sub.deliver(event)
+		}
+	}
+}
+
+// publishMonitorState notifies the subscribers if the overall state of the monitor has changed since it was last
+// published. The monitor mutex must be held by the caller so that events are delivered in order.
+func (mtr *Monitor) publishMonitorState() {
+	if len(mtr.subscriptions) == 0 {
+		return
+	}
+
+	state := mtr.currentState()
+	if state == mtr.publishedState {
+		return
+	}
+
+	event := Event{
+		Type:          EventMonitorStateChanged,
+		PreviousState: mtr.publishedState,
+		State:         state,
+		Timestamp:     time.Now(),
+	}
+	mtr.publishedState = state
+
+	for sub := range mtr.subscriptions {
+		sub.deliver(event)
+	}
+}
+
+// currentState determines the overall state of the monitor from the cached check statuses, degraded if they are
+// stale. The monitor mutex must be held by the caller.
+func (mtr *Monitor) currentState() State {
+	now := time.Now()
+	checkStatuses := make(map[string]CheckStatus, len(mtr.checkStatuses))
+	for checkName, checkStatus := range mtr.checkStatuses {
+		if runner, ok := mtr.runners[checkName]; ok {
+			checkStatus = runner.degradeStale(checkStatus, now)
+		}
+		checkStatuses[checkName] = checkStatus
+	}
+
+	return mtr.aggregateState(checkStatuses)
+}
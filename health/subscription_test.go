@@ -0,0 +1,185 @@
+package health_test
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/jaredpetersen/go-health/health"
+	"github.com/stretchr/testify/assert"
+)
+
+// receiveEvent receives the next event from the subscription, failing the test if one does not arrive in time.
+func receiveEvent(t *testing.T, sub *health.Subscription) health.Event {
+	select {
+	case event := <-sub.C:
+		return event
+	case <-time.After(time.Second):
+		assert.FailNow(t, "Event was not received")
+		return health.Event{}
+	}
+}
+
+func TestSubscribe(t *testing.T) {
+	healthMonitor := health.New()
+	ctx := context.Background()
+
+	sub := healthMonitor.Subscribe()
+	defer sub.Unsubscribe()
+
+	results := make(chan health.State, 10)
+	checkFunc := func(ctx context.Context) health.Status {
+		return health.Status{State: <-results}
+	}
+	check := health.NewCheck("check", checkFunc)
+	check.TTL = time.Millisecond
+	healthMonitor.Monitor(ctx, check)
+
+	// Registering the check brings the monitor down
+	event := receiveEvent(t, sub)
+	assert.Equal(t, health.EventMonitorStateChanged, event.Type)
+	assert.Equal(t, "", event.Check)
+	assert.Equal(t, health.StateUp, event.PreviousState)
+	assert.Equal(t, health.StateDown, event.State)
+
+	results <- health.StateWarn
+
+	event = receiveEvent(t, sub)
+	assert.Equal(t, health.EventCheckStateChanged, event.Type)
+	assert.Equal(t, check.Name, event.Check)
+	assert.Equal(t, health.StateDown, event.PreviousState)
+	assert.Equal(t, health.StateWarn, event.State)
+	assert.Equal(t, health.Status{State: health.StateWarn}, event.CheckStatus.Status)
+	assert.False(t, event.Timestamp.IsZero(), "Event timestamp was not set")
+
+	event = receiveEvent(t, sub)
+	assert.Equal(t, health.EventMonitorStateChanged, event.Type)
+	assert.Equal(t, health.StateDown, event.PreviousState)
+	assert.Equal(t, health.StateWarn, event.State)
+
+	// Results that do not change the state are not published
+	results <- health.StateWarn
+	results <- health.StateUp
+
+	event = receiveEvent(t, sub)
+	assert.Equal(t, health.EventCheckStateChanged, event.Type)
+	assert.Equal(t, health.StateWarn, event.PreviousState)
+	assert.Equal(t, health.StateUp, event.State)
+
+	event = receiveEvent(t, sub)
+	assert.Equal(t, health.EventMonitorStateChanged, event.Type)
+	assert.Equal(t, health.StateWarn, event.PreviousState)
+	assert.Equal(t, health.StateUp, event.State)
+
+	err := healthMonitor.Unregister(check.Name)
+	assert.NoError(t, err)
+
+	select {
+	case event := <-sub.C:
+		assert.Fail(t, "Unexpected event", event)
+	case <-time.After(time.Millisecond * 50):
+	}
+}
+
+func TestSubscribeExecutionEvents(t *testing.T) {
+	healthMonitor := health.New()
+	ctx := context.Background()
+
+	sub := healthMonitor.Subscribe(health.WithExecutionEvents())
+	defer sub.Unsubscribe()
+
+	checkFunc := func(ctx context.Context) health.Status {
+		return health.Status{State: health.StateDown}
+	}
+	check := health.NewCheck("check", checkFunc)
+	check.TTL = time.Millisecond * 10
+	healthMonitor.Monitor(ctx, check)
+
+	event := receiveEvent(t, sub)
+	assert.Equal(t, health.EventMonitorStateChanged, event.Type)
+
+	for i := 0; i < 3; i++ {
+		event = receiveEvent(t, sub)
+		assert.Equal(t, health.EventCheckExecuted, event.Type)
+		assert.Equal(t, check.Name, event.Check)
+		assert.Equal(t, health.StateDown, event.PreviousState)
+		assert.Equal(t, health.StateDown, event.State)
+		assert.Equal(t, 1+i, event.CheckStatus.ConsecutiveFailures)
+	}
+}
+
+func TestSubscribeDropsEventsForSlowSubscriber(t *testing.T) {
+	healthMonitor := health.New()
+	ctx := context.Background()
+
+	sub := healthMonitor.Subscribe(health.WithExecutionEvents(), health.WithSubscriptionBuffer(1))
+	defer sub.Unsubscribe()
+
+	checkFunc := func(ctx context.Context) health.Status {
+		return health.Status{State: health.StateUp}
+	}
+	check := health.NewCheck("check", checkFunc)
+	check.TTL = time.Millisecond
+	healthMonitor.Monitor(ctx, check)
+
+	// Wait for goroutines to kick in and execute a few times
+	time.Sleep(time.Millisecond * 100)
+
+	assert.Greater(t, sub.Dropped(), uint64(0), "Events were not dropped")
+
+	// The check continues to execute despite the subscriber not reading
+	timestampBefore := healthMonitor.Check().CheckStatuses[check.Name].Timestamp
+	time.Sleep(time.Millisecond * 50)
+	assert.True(t, healthMonitor.Check().CheckStatuses[check.Name].Timestamp.After(timestampBefore))
+}
+
+func TestSubscribeFunc(t *testing.T) {
+	healthMonitor := health.New()
+	ctx := context.Background()
+
+	var mtx sync.Mutex
+	var events []health.Event
+	sub := healthMonitor.SubscribeFunc(func(event health.Event) {
+		mtx.Lock()
+		events = append(events, event)
+		mtx.Unlock()
+	})
+	defer sub.Unsubscribe()
+
+	assert.Nil(t, sub.C)
+
+	checkFunc := func(ctx context.Context) health.Status {
+		return health.Status{State: health.StateUp}
+	}
+	check := health.NewCheck("check", checkFunc)
+	healthMonitor.Monitor(ctx, check)
+
+	// Wait for goroutines to kick in
+	time.Sleep(time.Millisecond * 100)
+
+	mtx.Lock()
+	defer mtx.Unlock()
+
+	if assert.Equal(t, 3, len(events)) {
+		assert.Equal(t, health.EventMonitorStateChanged, events[0].Type)
+		assert.Equal(t, health.StateDown, events[0].State)
+		assert.Equal(t, health.EventCheckStateChanged, events[1].Type)
+		assert.Equal(t, health.StateUp, events[1].State)
+		assert.Equal(t, health.EventMonitorStateChanged, events[2].Type)
+		assert.Equal(t, health.StateUp, events[2].State)
+	}
+}
+
+func TestUnsubscribe(t *testing.T) {
+	healthMonitor := health.New()
+
+	sub := healthMonitor.Subscribe()
+	sub.Unsubscribe()
+
+	// Unsubscribing multiple times is safe
+	sub.Unsubscribe()
+
+	_, ok := <-sub.C
+	assert.False(t, ok, "Subscription channel was not closed")
+}
@@ -0,0 +1,127 @@
+package health
+
+import (
+	"context"
+	"sync/atomic"
+	"time"
+)
+
+// TimeoutDetails is used as the status details when a check is configured to enforce its timeout and the check
+// function did not return in time.
+type TimeoutDetails struct {
+	// Check is the name of the check that timed out.
+	Check string
+	// Timeout is the configured timeout that was exceeded.
+	Timeout time.Duration
+	// Abandoned is the number of abandoned check function executions that are still running.
+	Abandoned int
+	// Skipped indicates that the check function was not executed because a previously abandoned execution is still
+	// running. Skipped executions are counted as timeouts.
+	Skipped bool
+}
+
+// executeCheckEnforcingTimeout executes the check function on a separate goroutine using the provided context, wrapped
+// with a deadline set to the check's configured timeout. If the check function does not return before the deadline,
+// the execution is abandoned and a StateDown status with TimeoutDetails is returned immediately. No new executions are
+// started while an abandoned execution is blocking, as defined by the check's abandon limit. False is returned if the
+// provided context is done before the check function returns, as the execution was interrupted rather than timed out.
+// The release function is called once the check function has returned, even if the execution was abandoned.
+func (runner *checkRunner) executeCheckEnforcingTimeout(ctx context.Context, release func()) (executionResult, bool) {
+	check := runner.check
+
+	if runner.isBlockedByAbandonedExecution() {
+		release()
+		return executionResult{
+			checkStatus: newTimeoutCheckStatus(check, int(atomic.LoadInt32(&runner.runningExecutions)), true, 0),
+			timedOut:    true,
+		}, true
+	}
+
+	start := time.Now()
+
+	timeoutCtx, cancelTimeout := context.WithTimeout(ctx, check.Timeout)
+
+	// Buffer the result channel so that an abandoned execution can finish without anyone listening
+	resultChan := make(chan executionResult, 1)
+	doneChan := make(chan struct{})
+
+	atomic.AddInt32(&runner.runningExecutions, 1)
+	runner.executions.Add(1)
+	go func() {
+		defer runner.executions.Done()
+		defer release()
+		defer atomic.AddInt32(&runner.runningExecutions, -1)
+		defer close(doneChan)
+		defer cancelTimeout()
+
+		result := executeCheck(timeoutCtx, check)
+		result.timedOut = timeoutCtx.Err() == context.DeadlineExceeded && ctx.Err() == nil
+		resultChan <- result
+	}()
+
+	select {
+	case result := <-resultChan:
+		return result, true
+	case <-timeoutCtx.Done():
+	}
+
+	// Prefer the result if the check function returned at the same time that the deadline was exceeded
+	select {
+	case result := <-resultChan:
+		return result, true
+	default:
+	}
+
+	// The execution was interrupted, e.g. by Stop, so it did not time out
+	if ctx.Err() != nil {
+		return executionResult{}, false
+	}
+
+	runner.abandoned = doneChan
+	runner.abandonedAt = time.Now()
+
+	abandoned := int(atomic.LoadInt32(&runner.runningExecutions))
+	return executionResult{
+		checkStatus: newTimeoutCheckStatus(check, abandoned, false, time.Since(start)),
+		timedOut:    true,
+	}, true
+}
+
+// isBlockedByAbandonedExecution determines if the most recently abandoned execution is still running and has not yet
+// exceeded the check's abandon limit.
+func (runner *checkRunner) isBlockedByAbandonedExecution() bool {
+	if runner.abandoned == nil {
+		return false
+	}
+
+	select {
+	case <-runner.abandoned:
+		runner.abandoned = nil
+		return false
+	default:
+	}
+
+	if runner.check.AbandonLimit > 0 && time.Since(runner.abandonedAt) >= runner.check.AbandonLimit {
+		runner.abandoned = nil
+		return false
+	}
+
+	return true
+}
+
+// newTimeoutCheckStatus creates a StateDown check status with TimeoutDetails.
+func newTimeoutCheckStatus(check Check, abandoned int, skipped bool, duration time.Duration) CheckStatus {
+	return CheckStatus{
+		Status: Status{
+			State: StateDown,
+			Details: TimeoutDetails{
+				Check:     check.Name,
+				Timeout:   check.Timeout,
+				Abandoned: abandoned,
+				Skipped:   skipped,
+			},
+		},
+		Timestamp: time.Now(),
+		Duration:  duration,
+	}
+}
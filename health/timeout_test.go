@@ -0,0 +1,208 @@
+package health_test
+
+import (
+	"context"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/jaredpetersen/go-health/health"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCheckEnforceTimeout(t *testing.T) {
+	healthMonitor := health.New()
+	ctx := context.Background()
+
+	release := make(chan struct{})
+	defer close(release)
+
+	var atomicCheckCounter int32
+	checkFunc := func(ctx context.Context) health.Status {
+		atomic.AddInt32(&atomicCheckCounter, 1)
+
+		// Ignore the context entirely
+		<-release
+		return health.Status{State: health.StateUp}
+	}
+	check := health.NewCheck("check", checkFunc)
+	check.TTL = time.Millisecond * 10
+	check.Timeout = time.Millisecond * 50
+	check.EnforceTimeout = true
+	healthMonitor.Monitor(ctx, check)
+
+	// Wait for goroutines to kick in and the timeout to be exceeded
+	time.Sleep(time.Millisecond * 100)
+
+	status := healthMonitor.Check()
+
+	assert.Equal(t, health.StateDown, status.State)
+
+	checkStatus := status.CheckStatuses[check.Name]
+	assert.False(t, checkStatus.Timestamp.IsZero(), "Check status timestamp was not updated")
+
+	details, ok := checkStatus.Status.Details.(health.TimeoutDetails)
+	if assert.True(t, ok, "Check status details are not timeout details") {
+		assert.Equal(t, check.Name, details.Check)
+		assert.Equal(t, check.Timeout, details.Timeout)
+		assert.Equal(t, 1, details.Abandoned)
+		assert.True(t, details.Skipped, "Check was not skipped while the abandoned execution was running")
+	}
+
+	assert.Equal(t, int32(1), atomic.LoadInt32(&atomicCheckCounter), "Overlapping check execution was started")
+}
+
+func TestCheckEnforceTimeoutResumesAfterReturn(t *testing.T) {
+	healthMonitor := health.New()
+	ctx := context.Background()
+
+	release := make(chan struct{})
+
+	var atomicCheckCounter int32
+	checkFunc := func(ctx context.Context) health.Status {
+		if atomic.AddInt32(&atomicCheckCounter, 1) == 1 {
+			// Ignore the context entirely on the first execution
+			<-release
+		}
+		return health.Status{State: health.StateUp}
+	}
+	check := health.NewCheck("check", checkFunc)
+	check.TTL = time.Millisecond * 10
+	check.Timeout = time.Millisecond * 50
+	check.EnforceTimeout = true
+	healthMonitor.Monitor(ctx, check)
+
+	// Wait for goroutines to kick in and the timeout to be exceeded
+	time.Sleep(time.Millisecond * 100)
+
+	assert.Equal(t, health.StateDown, healthMonitor.Check().State)
+
+	close(release)
+
+	// Wait for the next execution
+	time.Sleep(time.Millisecond * 50)
+
+	assert.Equal(t, health.Status{State: health.StateUp}, healthMonitor.Check().CheckStatuses[check.Name].Status)
+	assert.GreaterOrEqual(t, atomic.LoadInt32(&atomicCheckCounter), int32(2), "Check did not resume executing")
+}
+
+func TestCheckEnforceTimeoutAbandonLimit(t *testing.T) {
+	healthMonitor := health.New()
+	ctx := context.Background()
+
+	release := make(chan struct{})
+	defer close(release)
+
+	var atomicCheckCounter int32
+	checkFunc := func(ctx context.Context) health.Status {
+		atomic.AddInt32(&atomicCheckCounter, 1)
+
+		// Ignore the context entirely
+		<-release
+		return health.Status{State: health.StateUp}
+	}
+	check := health.NewCheck("check", checkFunc)
+	check.TTL = time.Millisecond * 10
+	check.Timeout = time.Millisecond * 30
+	check.EnforceTimeout = true
+	check.AbandonLimit = time.Millisecond * 50
+	healthMonitor.Monitor(ctx, check)
+
+	// Wait for goroutines to kick in and the abandon limit to be exceeded a couple of times
+	time.Sleep(time.Millisecond * 250)
+
+	assert.GreaterOrEqual(t, atomic.LoadInt32(&atomicCheckCounter), int32(2), "Check was not restarted")
+
+	details, ok := healthMonitor.Check().CheckStatuses[check.Name].Status.Details.(health.TimeoutDetails)
+	if assert.True(t, ok, "Check status details are not timeout details") {
+		assert.GreaterOrEqual(t, details.Abandoned, 2)
+	}
+}
+
+func TestCheckEnforceTimeoutReturnsInTime(t *testing.T) {
+	healthMonitor := health.New()
+	ctx := context.Background()
+
+	checkFunc := func(ctx context.Context) health.Status {
+		_, ok := ctx.Deadline()
+		assert.True(t, ok, "Check was not supplied with a deadline when timeout is specified")
+
+		return health.Status{State: health.StateWarn}
+	}
+	check := health.NewCheck("check", checkFunc)
+	check.Timeout = time.Second
+	check.EnforceTimeout = true
+	healthMonitor.Monitor(ctx, check)
+
+	// Wait for goroutines to kick in
+	time.Sleep(time.Millisecond * 100)
+
+	assert.Equal(t, health.Status{State: health.StateWarn}, healthMonitor.Check().CheckStatuses[check.Name].Status)
+}
+
+func TestCheckEnforceTimeoutCancelIsNotTimeout(t *testing.T) {
+	healthMonitor := health.New()
+	ctx, cancel := context.WithCancel(context.Background())
+
+	release := make(chan struct{})
+	defer close(release)
+
+	var atomicCheckCounter int32
+	checkFunc := func(ctx context.Context) health.Status {
+		if atomic.AddInt32(&atomicCheckCounter, 1) == 1 {
+			return health.Status{State: health.StateUp}
+		}
+
+		// Ignore the context entirely
+		<-release
+		return health.Status{State: health.StateDown}
+	}
+	check := health.NewCheck("check", checkFunc)
+	check.TTL = time.Millisecond * 10
+	check.Timeout = time.Hour
+	check.EnforceTimeout = true
+	healthMonitor.Monitor(ctx, check)
+
+	// Wait for goroutines to kick in
+	time.Sleep(time.Millisecond * 50)
+
+	cancel()
+
+	// Wait for cancel to kick in
+	time.Sleep(time.Millisecond * 50)
+
+	checkStatus := healthMonitor.Check().CheckStatuses[check.Name]
+	assert.Equal(t, health.StateUp, checkStatus.Status.State)
+	assert.Equal(t, uint64(0), checkStatus.Timeouts)
+}
+
+func TestCheckEnforceTimeoutStopWaitsForAbandoned(t *testing.T) {
+	healthMonitor := health.New()
+	ctx := context.Background()
+
+	release := make(chan struct{})
+	defer close(release)
+
+	checkFunc := func(ctx context.Context) health.Status {
+		// Ignore the context entirely
+		<-release
+		return health.Status{State: health.StateUp}
+	}
+	check := health.NewCheck("check", checkFunc)
+	check.Timeout = time.Millisecond * 10
+	check.EnforceTimeout = true
+	healthMonitor.Monitor(ctx, check)
+
+	// Wait for goroutines to kick in and the timeout to be exceeded
+	time.Sleep(time.Millisecond * 50)
+
+	stopCtx, cancelStop := context.WithTimeout(context.Background(), time.Millisecond*50)
+	defer cancelStop()
+
+	err := healthMonitor.Stop(stopCtx)
+
+	var stopErr *health.StopError
+	if assert.ErrorAs(t, err, &stopErr) {
+		assert.Equal(t, []string{"check"}, stopErr.Checks)
+	}
+}
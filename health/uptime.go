@@ -0,0 +1,169 @@
+package health
+
+import (
+	"fmt"
+	"time"
+)
+
+// defaultUptimeWindows are the rolling windows that uptime is calculated over unless configured otherwise.
+var defaultUptimeWindows = []time.Duration{time.Hour, time.Hour * 24, time.Hour * 24 * 30}
+
+// WithUptimeWindows configures the rolling windows that uptime is calculated over for checks that track uptime.
+// Defaults to one hour, 24 hours, and 30 days. Windows less than or equal to zero are ignored.
+func WithUptimeWindows(windows ...time.Duration) Option {
+	return func(mtr *Monitor) {
+		mtr.uptimeWindows = nil
+		for _, window := range windows {
+			if window > 0 {
+				mtr.uptimeWindows = append(mtr.uptimeWindows, window)
+			}
+		}
+	}
+}
+
+// Uptime describes the time that a check spent in each state over a rolling window.
+type Uptime struct {
+	// Window is the duration of the rolling window, ending now.
+	Window time.Duration
+	// Observed is the time within the window that the check has been executing. It is less than the window if the
+	// check was registered within the window.
+	Observed time.Duration
+	// Up is the time within the window that the check spent in StateUp.
+	Up time.Duration
+	// Warn is the time within the window that the check spent in StateWarn.
+	Warn time.Duration
+	// Down is the time within the window that the check spent in StateDown.
+	Down time.Duration
+	// Percentage is the percentage (0 to 100) of the observed time that the check spent in StateWarn or StateUp. A
+	// check that has not yet been observed is reported as 100.
+	Percentage float64
+	// BurnRate is the rate at which the error budget defined by the check's SLO target is being consumed over the
+	// window. A burn rate of one consumes exactly the error budget, greater than one exceeds it. Zero if the check does
+	// not have an SLO target.
+	BurnRate float64
+}
+
+// stateTransition marks the state of a check from a point in time onwards.
+type stateTransition struct {
+	// at is the time the check transitioned to the state.
+	at time.Time
+	// state is the state the check transitioned to.
+	state State
+}
+
+// uptimeTracker records the state transitions of a check so that the time spent in each state may be calculated.
+type uptimeTracker struct {
+	// retention is the duration that transitions are needed for, i.e. the longest uptime window.
+	retention time.Duration
+	// transitions contains the state transitions of the check ordered from oldest to newest.
+	transitions []stateTransition
+}
+
+// newUptimeTracker creates an uptime tracker that retains enough transitions to calculate uptime over the retention.
+func newUptimeTracker(retention time.Duration) *uptimeTracker {
+	return &uptimeTracker{retention: retention}
+}
+
+// record notes the state of the check at the provided time, discarding transitions that are no longer needed.
+func (tracker *uptimeTracker) record(at time.Time, state State) {
+	if len(tracker.transitions) == 0 || tracker.transitions[len(tracker.transitions)-1].state != state {
+		tracker.transitions = append(tracker.transitions, stateTransition{at: at, state: state})
+	}
+
+	// Keep the transition that straddles the start of the retention so that the state at that time is known
+	cutoff := at.Add(-tracker.retention)
+	expired := 0
+	for expired+1 < len(tracker.transitions) && !tracker.transitions[expired+1].at.After(cutoff) {
+		expired++
+	}
+
+	if expired > 0 {
+		tracker.transitions = append(tracker.transitions[:0], tracker.transitions[expired:]...)
+	}
+}
+
+// calculate determines the uptime for each of the windows ending at the provided time.
+func (tracker *uptimeTracker) calculate(now time.Time, windows []time.Duration, sloTarget float64) []Uptime {
+	uptimes := make([]Uptime, len(windows))
+	for i, window := range windows {
+		uptimes[i] = tracker.calculateWindow(now, window, sloTarget)
+	}
+
+	return uptimes
+}
+
+// calculateWindow determines the uptime for the window ending at the provided time.
+func (tracker *uptimeTracker) calculateWindow(now time.Time, window time.Duration, sloTarget float64) Uptime {
+	uptime := Uptime{Window: window}
+	windowStart := now.Add(-window)
+
+	for i, transition := range tracker.transitions {
+		start := transition.at
+		if start.Before(windowStart) {
+			start = windowStart
+		}
+
+		end := now
+		if i+1 < len(tracker.transitions) {
+			end = tracker.transitions[i+1].at
+		}
+
+		if !end.After(start) {
+			continue
+		}
+
+		duration := end.Sub(start)
+		switch transition.state {
+		case StateUp:
+			uptime.Up += duration
+		case StateWarn:
+			uptime.Warn += duration
+		default:
+			uptime.Down += duration
+		}
+		uptime.Observed += duration
+	}
+
+	uptime.Percentage = 100
+	if uptime.Observed > 0 {
+		uptime.Percentage = float64(uptime.Up+uptime.Warn) / float64(uptime.Observed) * 100
+	}
+
+	if sloTarget > 0 && sloTarget < 1 {
+		errorRate := 1 - uptime.Percentage/100
+		uptime.BurnRate = errorRate / (1 - sloTarget)
+	}
+
+	return uptime
+}
+
+// Uptime returns the time that the check with the provided name spent in each state over each of the monitor's uptime
+// windows. The result is empty if the check is not configured to track uptime. Tracking is reset when the check is
+// replaced. An error wrapping ErrCheckNotFound is returned if no check with the provided name is registered.
+func (mtr *Monitor) Uptime(name string) ([]Uptime, error) {
+	mtr.mtx.RLock()
+	defer mtr.mtx.RUnlock()
+
+	runner, ok := mtr.runners[name]
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", ErrCheckNotFound, name)
+	}
+
+	if runner.uptime == nil {
+		return []Uptime{}, nil
+	}
+
+	return runner.uptime.calculate(time.Now(), mtr.uptimeWindows, runner.check.SLOTarget), nil
+}
+
+// maxDuration returns the longest of the provided durations.
+func maxDuration(durations []time.Duration) time.Duration {
+	var max time.Duration
+	for _, duration := range durations {
+		if duration > max {
+			max = duration
+		}
+	}
+
+	return max
+}
@@ -0,0 +1,127 @@
+package health_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/jaredpetersen/go-health/health"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestUptime(t *testing.T) {
+	healthMonitor := health.New(health.WithUptimeWindows(time.Millisecond*100, time.Hour))
+	ctx := context.Background()
+
+	states := make(chan health.State)
+	state := health.StateUp
+	checkFunc := func(ctx context.Context) health.Status {
+		select {
+		case state = <-states:
+		default:
+		}
+		return health.Status{State: state}
+	}
+	check := health.NewCheck("check", checkFunc)
+	check.TTL = time.Millisecond
+	check.TrackUptime = true
+	check.SLOTarget = 0.9
+	healthMonitor.Monitor(ctx, check)
+
+	// Spend some time up
+	time.Sleep(time.Millisecond * 150)
+
+	uptimes, err := healthMonitor.Uptime(check.Name)
+	assert.NoError(t, err)
+	if assert.Equal(t, 2, len(uptimes)) {
+		assert.Equal(t, time.Millisecond*100, uptimes[0].Window)
+		assert.Equal(t, time.Millisecond*100, uptimes[0].Observed)
+		assert.Equal(t, time.Millisecond*100, uptimes[0].Up)
+		assert.Equal(t, float64(100), uptimes[0].Percentage)
+		assert.Equal(t, float64(0), uptimes[0].BurnRate)
+
+		assert.Equal(t, time.Hour, uptimes[1].Window)
+		assert.Less(t, uptimes[1].Observed, time.Millisecond*200)
+	}
+
+	// Spend some time down
+	states <- health.StateDown
+	time.Sleep(time.Millisecond * 50)
+
+	uptimes, err = healthMonitor.Uptime(check.Name)
+	assert.NoError(t, err)
+	if assert.Equal(t, 2, len(uptimes)) {
+		assert.Equal(t, time.Millisecond*100, uptimes[0].Observed)
+		assert.Equal(t, uptimes[0].Observed, uptimes[0].Up+uptimes[0].Warn+uptimes[0].Down)
+		assert.InDelta(t, 50, uptimes[0].Percentage, 15)
+		assert.InDelta(t, 5, uptimes[0].BurnRate, 1.5)
+
+		assert.Greater(t, uptimes[1].Percentage, uptimes[0].Percentage)
+	}
+
+	// Spend the whole window down
+	time.Sleep(time.Millisecond * 100)
+
+	uptimes, err = healthMonitor.Uptime(check.Name)
+	assert.NoError(t, err)
+	if assert.Equal(t, 2, len(uptimes)) {
+		assert.Equal(t, time.Millisecond*100, uptimes[0].Down)
+		assert.Equal(t, float64(0), uptimes[0].Percentage)
+		assert.InDelta(t, 10, uptimes[0].BurnRate, 0.0001)
+	}
+
+	// Uptime is also available on the check status
+	checkStatus := healthMonitor.Check().CheckStatuses[check.Name]
+	assert.Equal(t, 2, len(checkStatus.Uptime))
+}
+
+func TestUptimeNotTracked(t *testing.T) {
+	healthMonitor := health.New()
+	ctx := context.Background()
+
+	checkFunc := func(ctx context.Context) health.Status {
+		return health.Status{State: health.StateUp}
+	}
+	check := health.NewCheck("check", checkFunc)
+	healthMonitor.Monitor(ctx, check)
+
+	// Wait for goroutines to kick in
+	time.Sleep(time.Millisecond * 50)
+
+	uptimes, err := healthMonitor.Uptime(check.Name)
+	assert.NoError(t, err)
+	assert.Empty(t, uptimes)
+	assert.Nil(t, healthMonitor.Check().CheckStatuses[check.Name].Uptime)
+}
+
+func TestUptimeNotObserved(t *testing.T) {
+	healthMonitor := health.New()
+	ctx := context.Background()
+
+	release := make(chan struct{})
+	defer close(release)
+
+	checkFunc := func(ctx context.Context) health.Status {
+		<-release
+		return health.Status{State: health.StateUp}
+	}
+	check := health.NewCheck("check", checkFunc)
+	check.TrackUptime = true
+	healthMonitor.Monitor(ctx, check)
+
+	uptimes, err := healthMonitor.Uptime(check.Name)
+	assert.NoError(t, err)
+	if assert.Equal(t, 3, len(uptimes)) {
+		assert.Equal(t, []time.Duration{time.Hour, time.Hour * 24, time.Hour * 24 * 30},
+			[]time.Duration{uptimes[0].Window, uptimes[1].Window, uptimes[2].Window})
+		assert.Equal(t, time.Duration(0), uptimes[0].Observed)
+		assert.Equal(t, float64(100), uptimes[0].Percentage)
+	}
+}
+
+func TestUptimeNotFound(t *testing.T) {
+	healthMonitor := health.New()
+
+	_, err := healthMonitor.Uptime("check")
+	assert.ErrorIs(t, err, health.ErrCheckNotFound)
+}